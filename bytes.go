@@ -0,0 +1,143 @@
+package rand
+
+import (
+	"io"
+)
+
+// Reader is a global, shared instance of a cryptographically secure random
+// number generator implementing io.Reader. It reads from the default
+// Generator, so it follows the same source and fallback rules as the other
+// package-level functions: it falls back to math/rand when the entropy source
+// fails unless strict mode is enabled (see SetStrict), in which case it
+// returns an error wrapping ErrEntropyUnavailable.
+//
+// Enable strict mode before passing Reader to APIs that derive secrets from it,
+// so that key generation never silently uses predictable bytes.
+//
+// Example:
+//
+//	rand.SetStrict(true)
+//	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+//	cfg := &tls.Config{Rand: rand.Reader}
+var Reader io.Reader = defaultGenerator
+
+// Bool returns a cryptographically secure random bool, true or false with equal probability.
+//
+// Example:
+//
+//	heads := rand.Bool()
+func Bool() bool {
+	return defaultGenerator.Bool()
+}
+
+// Bool is like the package-level Bool but draws from g.
+func (g *Generator) Bool() bool {
+	return must(g.BoolE())
+}
+
+// BoolE is like Bool but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func BoolE() (bool, error) {
+	return defaultGenerator.BoolE()
+}
+
+// BoolE is like the package-level BoolE but draws from g.
+func (g *Generator) BoolE() (bool, error) {
+	n, err := g.uint64()
+	return n>>63 == 1, err
+}
+
+// Chance returns true with probability p.
+// It always returns false if p <= 0 or p is NaN, and always true if p >= 1,
+// without drawing from the entropy source.
+//
+// Example:
+//
+//	if rand.Chance(0.05) {
+//		// Runs for about 5% of calls
+//	}
+func Chance(p float64) bool {
+	return defaultGenerator.Chance(p)
+}
+
+// Chance is like the package-level Chance but draws from g.
+func (g *Generator) Chance(p float64) bool {
+	return must(g.ChanceE(p))
+}
+
+// ChanceE is like Chance but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func ChanceE(p float64) (bool, error) {
+	return defaultGenerator.ChanceE(p)
+}
+
+// ChanceE is like the package-level ChanceE but draws from g.
+func (g *Generator) ChanceE(p float64) (bool, error) {
+	if !(p > 0) { // Also catches NaN
+		return false, nil
+	}
+	if p >= 1 {
+		return true, nil
+	}
+
+	u, err := g.Float64E()
+	return u < p, err
+}
+
+// Bytes returns a slice of n cryptographically secure random bytes.
+// It returns an empty slice if n <= 0.
+//
+// Example:
+//
+//	nonce := rand.Bytes(12)
+func Bytes(n int) []byte {
+	return defaultGenerator.Bytes(n)
+}
+
+// Bytes is like the package-level Bytes but draws from g.
+func (g *Generator) Bytes(n int) []byte {
+	return must(g.BytesE(n))
+}
+
+// BytesE is like Bytes but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func BytesE(n int) ([]byte, error) {
+	return defaultGenerator.BytesE(n)
+}
+
+// BytesE is like the package-level BytesE but draws from g.
+func (g *Generator) BytesE(n int) ([]byte, error) {
+	if n <= 0 {
+		return []byte{}, nil
+	}
+
+	b := make([]byte, n)
+	if err := g.read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Read fills p with cryptographically secure random bytes. It always returns
+// len(p) and a nil error, unless strict mode is enabled (see SetStrict) and the
+// entropy source fails, in which case it returns 0 and an error wrapping
+// ErrEntropyUnavailable.
+//
+// Example:
+//
+//	var key [32]byte
+//	if _, err := rand.Read(key[:]); err != nil {
+//		// Handle error
+//	}
+func Read(p []byte) (n int, err error) {
+	return defaultGenerator.Read(p)
+}
+
+// Read is like the package-level Read but draws from g.
+// It makes every Generator an io.Reader.
+func (g *Generator) Read(p []byte) (n int, err error) {
+	if err := g.read(p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,127 @@
+package rand
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBool validates the Bool function
+func TestBool(t *testing.T) {
+	const iterations = 100000
+
+	trues := 0
+	for i := 0; i < iterations; i++ {
+		if Bool() {
+			trues++
+		}
+	}
+	assert.InDelta(t, iterations/2, trues, 1000, "Bool should be true about half the time")
+}
+
+// TestChance validates the Chance function
+func TestChance(t *testing.T) {
+	const iterations = 100000
+
+	hits := 0
+	for i := 0; i < iterations; i++ {
+		if Chance(0.1) {
+			hits++
+		}
+	}
+	assert.InDelta(t, iterations/10, hits, 600, "Chance(0.1) should hit about 10% of the time")
+
+	// Degenerate probabilities never touch the source
+	strict := NewGenerator(failingSource{}, WithStrict())
+	for _, p := range []float64{0, -1, math.NaN(), math.Inf(-1)} {
+		assert.False(t, strict.Chance(p), "Chance(%g)", p)
+	}
+	for _, p := range []float64{1, 2, math.Inf(1)} {
+		assert.True(t, strict.Chance(p), "Chance(%g)", p)
+	}
+
+	_, err := strict.ChanceE(0.5)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = strict.BoolE()
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+}
+
+// TestBytes validates the Bytes function
+func TestBytes(t *testing.T) {
+	for _, n := range []int{1, 7, 32, 1000, 5000} {
+		b := Bytes(n)
+		assert.Len(t, b, n)
+	}
+
+	assert.Empty(t, Bytes(0))
+	assert.Empty(t, Bytes(-1))
+	assert.NotEqual(t, Bytes(32), Bytes(32), "Bytes should not repeat")
+
+	// Seeded generators produce the same bytes through Bytes and Read
+	b := NewSeeded(42).Bytes(64)
+	p := make([]byte, 64)
+	n, err := NewSeeded(42).Read(p)
+	require.NoError(t, err)
+	assert.Equal(t, 64, n)
+	assert.Equal(t, b, p)
+
+	strict := NewGenerator(failingSource{}, WithStrict())
+	b, err = strict.BytesE(16)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	assert.Nil(t, b)
+	assert.Panics(t, func() { strict.Bytes(16) })
+
+	// Without strict mode the fallback still fills the slice
+	assert.Len(t, NewGenerator(failingSource{}).Bytes(16), 16)
+}
+
+// TestRead validates Read and the io.Reader implementations
+func TestRead(t *testing.T) {
+	p := make([]byte, 100)
+	n, err := Read(p)
+	require.NoError(t, err)
+	assert.Equal(t, 100, n)
+	assert.NotEqual(t, make([]byte, 100), p)
+
+	// Reader can be consumed by standard io helpers
+	buf, err := io.ReadAll(io.LimitReader(Reader, 4096))
+	require.NoError(t, err)
+	assert.Len(t, buf, 4096)
+
+	strict := NewGenerator(failingSource{}, WithStrict())
+	n, err = strict.Read(p)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	assert.Equal(t, 0, n)
+
+	// A Generator can serve as the Source of another Generator
+	g := NewGenerator(NewSeeded(7))
+	assert.Equal(t, NewSeeded(7).Bytes(16), g.Bytes(16))
+}
+
+// TestReaderCrypto validates that Reader works with standard library crypto APIs
+func TestReaderCrypto(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), Reader)
+	require.NoError(t, err)
+
+	digest := sha256.Sum256([]byte("tsrand"))
+	sig, err := ecdsa.SignASN1(Reader, key, digest[:])
+	require.NoError(t, err)
+	assert.True(t, ecdsa.VerifyASN1(&key.PublicKey, digest[:], sig))
+	assert.False(t, bytes.Equal(sig, make([]byte, len(sig))))
+}
+
+// BenchmarkBytes benchmarks the Bytes function
+func BenchmarkBytes(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Bytes(32)
+	}
+}
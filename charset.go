@@ -0,0 +1,239 @@
+package rand
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidCharset is returned when a Charset would be empty or its characters are not valid UTF-8
+var ErrInvalidCharset = errors.New("invalid charset")
+
+// Ready-made character sets for StringFrom
+var (
+	// HexCharset contains the lowercase hexadecimal digits 0-9 and a-f
+	HexCharset = MustCharset("0123456789abcdef")
+
+	// Base32Charset contains the RFC 4648 base32 alphabet: A-Z and 2-7
+	Base32Charset = MustCharset("ABCDEFGHIJKLMNOPQRSTUVWXYZ234567")
+
+	// Base58Charset contains the Bitcoin base58 alphabet: the alphanumeric
+	// characters without 0, O, I and l
+	Base58Charset = MustCharset("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+
+	// URLSafeCharset contains the RFC 4648 base64url alphabet: A-Z, a-z, 0-9,
+	// "-" and "_", none of which need escaping in URLs or file names
+	URLSafeCharset = MustCharset("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_")
+
+	// SymbolsCharset contains PasswordSymbols
+	SymbolsCharset = MustCharset(PasswordSymbols)
+
+	// VisibleCharset contains VisibleLetters
+	VisibleCharset = MustCharset(VisibleLetters)
+)
+
+// Charset is a set of distinct characters to generate strings from with
+// StringFrom. It is prepared once by NewCharset, so generating from it costs
+// no conversion, and it is immutable and safe for concurrent use.
+//
+// A usable Charset comes from NewCharset, MustCharset, a preset such as
+// HexCharset, or a set operation on one of those. The zero value is an empty
+// set, from which StringFrom generates nothing and StringFromSafe returns an
+// error.
+type Charset struct {
+	chars []rune // in the order first given
+	s     string // chars as a string
+	ascii bool   // every character is a single byte, so s can be indexed directly
+}
+
+// NewCharset returns the set of characters in chars. A character given more
+// than once is kept once, so every character is equally likely in StringFrom.
+//
+// It returns an error wrapping ErrInvalidCharset if chars is empty or not
+// valid UTF-8.
+//
+// Example:
+//
+//	cs, err := rand.NewCharset("ACGT")
+//	if err != nil {
+//		// Handle error
+//	}
+//	dna := rand.StringFrom(cs, 20)
+func NewCharset(chars string) (*Charset, error) {
+	if !utf8.ValidString(chars) {
+		return nil, fmt.Errorf("%w: %q is not valid UTF-8", ErrInvalidCharset, chars)
+	}
+	if chars == "" {
+		return nil, fmt.Errorf("%w: no characters", ErrInvalidCharset)
+	}
+	return charsetOf([]rune(chars)), nil
+}
+
+// MustCharset is like NewCharset but panics if chars is invalid. It is meant
+// for character sets known when the program is written.
+//
+// Example:
+//
+//	var dnaCharset = rand.MustCharset("ACGT")
+func MustCharset(chars string) *Charset {
+	c, err := NewCharset(chars)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// charsetOf returns the Charset of the distinct runes, keeping their first occurrences
+func charsetOf(runes []rune) *Charset {
+	seen := make(map[rune]bool, len(runes))
+	chars := make([]rune, 0, len(runes))
+	for _, r := range runes {
+		if !seen[r] {
+			seen[r] = true
+			chars = append(chars, r)
+		}
+	}
+	s := string(chars)
+	return &Charset{chars: chars, s: s, ascii: len(s) == len(chars)}
+}
+
+// Len returns the number of characters in the set
+func (c *Charset) Len() int {
+	return len(c.chars)
+}
+
+// Contains reports whether r is in the set
+func (c *Charset) Contains(r rune) bool {
+	return strings.ContainsRune(c.s, r)
+}
+
+// String returns the characters of the set in the order they were first given
+func (c *Charset) String() string {
+	return c.s
+}
+
+// Union returns the characters in c or other, those of c first.
+//
+// Example:
+//
+//	cs := rand.HexCharset.Union(rand.SymbolsCharset)
+func (c *Charset) Union(other *Charset) *Charset {
+	runes := make([]rune, 0, len(c.chars)+len(other.chars))
+	return charsetOf(append(append(runes, c.chars...), other.chars...))
+}
+
+// Minus returns the characters of c that are not in other.
+//
+// It returns an error wrapping ErrInvalidCharset if no character is left.
+//
+// Example:
+//
+//	// Base58 without the vowels that could spell words
+//	cs, err := rand.Base58Charset.Minus(rand.MustCharset("aeiouAEIOU"))
+func (c *Charset) Minus(other *Charset) (*Charset, error) {
+	return c.filter(func(r rune) bool { return !other.Contains(r) })
+}
+
+// Intersect returns the characters of c that are also in other.
+//
+// It returns an error wrapping ErrInvalidCharset if no character is left.
+//
+// Example:
+//
+//	// The uppercase letters and digits that are easy to read
+//	cs, err := rand.VisibleCharset.Intersect(rand.Base32Charset)
+func (c *Charset) Intersect(other *Charset) (*Charset, error) {
+	return c.filter(other.Contains)
+}
+
+// filter returns the characters of c for which keep returns true
+func (c *Charset) filter(keep func(rune) bool) (*Charset, error) {
+	var runes []rune
+	for _, r := range c.chars {
+		if keep(r) {
+			runes = append(runes, r)
+		}
+	}
+	if len(runes) == 0 {
+		return nil, fmt.Errorf("%w: no characters left", ErrInvalidCharset)
+	}
+	return charsetOf(runes), nil
+}
+
+// StringFrom generates a cryptographically secure random string of the
+// specified length with every character drawn uniformly from cs.
+//
+// Unlike CustomString it needs no per-call conversion of the character set
+// and cannot be skewed by duplicate characters.
+//
+// Parameters:
+//   - cs: the character set, from NewCharset or a preset
+//   - length: the desired length of the generated string
+//
+// Returns:
+//   - A random string of the specified length; empty if length <= 0, or if
+//     cs is nil or empty, as the zero Charset is
+//
+// Example:
+//
+//	id := rand.StringFrom(rand.Base58Charset, 22)
+func StringFrom(cs *Charset, length int) string {
+	return defaultGenerator.StringFrom(cs, length)
+}
+
+// StringFrom is like the package-level StringFrom but draws from g.
+func (g *Generator) StringFrom(cs *Charset, length int) string {
+	return must(g.StringFromE(cs, length))
+}
+
+// StringFromE is like StringFrom but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func StringFromE(cs *Charset, length int) (string, error) {
+	return defaultGenerator.StringFromE(cs, length)
+}
+
+// StringFromE is like the package-level StringFromE but draws from g.
+func (g *Generator) StringFromE(cs *Charset, length int) (string, error) {
+	s, err := g.StringFromSafe(cs, length)
+	if errors.Is(err, ErrInvalidCharset) {
+		return "", nil
+	}
+	return s, err
+}
+
+// StringFromSafe generates a cryptographically secure random string of the
+// specified length with every character drawn uniformly from cs.
+//
+// Parameters:
+//   - cs: the character set, from NewCharset or a preset
+//   - length: the desired length of the generated string
+//
+// Returns:
+//   - A random string of the specified length; empty if length <= 0
+//   - An error wrapping ErrInvalidCharset if cs is nil or empty, as the zero
+//     Charset is, or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	id, err := rand.StringFromSafe(cs, 22)
+//	if err != nil {
+//		// Handle error
+//	}
+func StringFromSafe(cs *Charset, length int) (string, error) {
+	return defaultGenerator.StringFromSafe(cs, length)
+}
+
+// StringFromSafe is like the package-level StringFromSafe but draws from g.
+func (g *Generator) StringFromSafe(cs *Charset, length int) (string, error) {
+	if cs == nil || len(cs.chars) == 0 {
+		return "", fmt.Errorf("%w: no characters to draw from", ErrInvalidCharset)
+	}
+	if length <= 0 {
+		return "", nil
+	}
+	if cs.ascii {
+		return g.randStringFromBytes(cs.s, length)
+	}
+	return g.randStringFromRunes(cs.chars, length)
+}
@@ -0,0 +1,172 @@
+package rand
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewCharset checks deduplication, order and validation
+func TestNewCharset(t *testing.T) {
+	cs, err := NewCharset("abcabba")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", cs.String())
+	assert.Equal(t, 3, cs.Len())
+	assert.True(t, cs.Contains('b'))
+	assert.False(t, cs.Contains('d'))
+
+	cs, err = NewCharset("αβαγ€€x")
+	require.NoError(t, err)
+	assert.Equal(t, "αβγ€x", cs.String())
+	assert.Equal(t, 5, cs.Len())
+
+	for name, chars := range map[string]string{
+		"empty":         "",
+		"invalid UTF-8": "ab\xffc",
+		"truncated":     "a\xe2\x82",
+	} {
+		_, err := NewCharset(chars)
+		assert.ErrorIs(t, err, ErrInvalidCharset, name)
+	}
+
+	assert.Equal(t, "ACGT", MustCharset("ACGTTGCA").String())
+	assert.Panics(t, func() { MustCharset("") })
+}
+
+// TestCharsetPresets checks the sizes and contents of the presets
+func TestCharsetPresets(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cs   *Charset
+		size int
+	}{
+		"hex":     {HexCharset, 16},
+		"base32":  {Base32Charset, 32},
+		"base58":  {Base58Charset, 58},
+		"urlsafe": {URLSafeCharset, 64},
+		"symbols": {SymbolsCharset, len(PasswordSymbols)},
+		"visible": {VisibleCharset, len(VisibleLetters)},
+	} {
+		assert.Equal(t, tc.size, tc.cs.Len(), name)
+		assert.Equal(t, tc.size, utf8.RuneCountInString(tc.cs.String()), name)
+	}
+
+	for _, r := range "0OIl" {
+		assert.False(t, Base58Charset.Contains(r), "base58 contains %q", r)
+	}
+	for _, r := range URLSafeCharset.String() {
+		assert.NotContains(t, "+/=%?&#", string(r))
+	}
+}
+
+// TestCharsetAlgebra checks Union, Minus and Intersect
+func TestCharsetAlgebra(t *testing.T) {
+	abc, bcd := MustCharset("abc"), MustCharset("dcb")
+
+	assert.Equal(t, "abcd", abc.Union(bcd).String())
+	assert.Equal(t, "dcba", bcd.Union(abc).String())
+	assert.Equal(t, "abc", abc.Union(abc).String())
+
+	cs, err := abc.Minus(bcd)
+	require.NoError(t, err)
+	assert.Equal(t, "a", cs.String())
+
+	cs, err = bcd.Intersect(abc)
+	require.NoError(t, err)
+	assert.Equal(t, "cb", cs.String())
+
+	_, err = abc.Minus(abc.Union(bcd))
+	assert.ErrorIs(t, err, ErrInvalidCharset)
+	_, err = abc.Intersect(MustCharset("xyz"))
+	assert.ErrorIs(t, err, ErrInvalidCharset)
+
+	// The operands are left unchanged
+	assert.Equal(t, "abc", abc.String())
+	assert.Equal(t, "dcb", bcd.String())
+
+	// Presets combine as expected
+	cs, err = VisibleCharset.Intersect(Base32Charset)
+	require.NoError(t, err)
+	assert.Equal(t, "234567ABCDEFGHJKLMNPQRSTUVWXYZ", cs.String())
+
+	cs, err = URLSafeCharset.Minus(HexCharset)
+	require.NoError(t, err)
+	assert.Equal(t, 64-16, cs.Len())
+
+	mixed := MustCharset("ab").Union(MustCharset("é"))
+	assert.False(t, mixed.ascii)
+	cs, err = mixed.Minus(MustCharset("é"))
+	require.NoError(t, err)
+	assert.True(t, cs.ascii)
+}
+
+// TestStringFrom checks lengths, contents and uniformity
+func TestStringFrom(t *testing.T) {
+	for _, cs := range []*Charset{HexCharset, Base58Charset, MustCharset("αβγ€x")} {
+		for _, length := range []int{1, 10, 100} {
+			s := StringFrom(cs, length)
+			assert.Equal(t, length, utf8.RuneCountInString(s))
+			for _, r := range s {
+				assert.True(t, cs.Contains(r), "%q not in %q", r, cs)
+			}
+		}
+	}
+	assert.Empty(t, StringFrom(HexCharset, 0))
+	assert.Empty(t, StringFrom(HexCharset, -1))
+
+	// Duplicates are not drawn more often, unlike with CustomString
+	cs, err := NewCharset("aaaaaaabc€")
+	require.NoError(t, err)
+	const draws = 120000
+	counts := make(map[int64]int)
+	for _, r := range NewSeeded(25).StringFrom(cs, draws) {
+		counts[int64(strings.IndexRune(cs.String(), r))]++
+	}
+	stat, critical := chiSquareGOF(counts, draws, 0, 3, func(int64) float64 { return 0.25 })
+	assert.Less(t, stat, critical)
+
+	// A Charset draws the same characters as CustomString with its characters
+	assert.Equal(t, NewSeeded(26).CustomString("0123456789abcdef", 40), NewSeeded(26).StringFrom(HexCharset, 40))
+	assert.Equal(t, NewSeeded(27).CustomString("αβγ€x", 40), NewSeeded(27).StringFrom(MustCharset("αβγ€x"), 40))
+
+	// The zero Charset is empty and cannot generate anything
+	var zero Charset
+	assert.Equal(t, 0, zero.Len())
+	for _, cs := range []*Charset{&zero, nil, zero.Union(&zero)} {
+		assert.Empty(t, StringFrom(cs, 4))
+		s, err := StringFromSafe(cs, 4)
+		assert.ErrorIs(t, err, ErrInvalidCharset)
+		assert.Empty(t, s)
+
+		// Like CustomStringE with an empty charset, StringFromE only reports entropy failures
+		s, err = StringFromE(cs, 4)
+		assert.NoError(t, err)
+		assert.Empty(t, s)
+	}
+	assert.Equal(t, "ab", zero.Union(MustCharset("ab")).String())
+	s, err := StringFromSafe(HexCharset, 12)
+	require.NoError(t, err)
+	assert.Len(t, s, 12)
+
+	strict := NewGenerator(failingSource{}, WithStrict())
+	_, err = strict.StringFromE(Base32Charset, 8)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = strict.StringFromSafe(Base32Charset, 8)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+}
+
+func BenchmarkStringFrom(b *testing.B) {
+	b.Run("Charset", func(b *testing.B) {
+		cs := MustCharset("αβγδεζηθικλμνξοπρστυφχψω")
+		for i := 0; i < b.N; i++ {
+			_ = StringFrom(cs, 16)
+		}
+	})
+	b.Run("CustomString", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = CustomString("αβγδεζηθικλμνξοπρστυφχψω", 16)
+		}
+	})
+}
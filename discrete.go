@@ -0,0 +1,589 @@
+package rand
+
+import (
+	"math"
+)
+
+// maxPoissonLambda bounds the Poisson mean so that samples fit in an int64
+// with overwhelming probability
+const maxPoissonLambda = 1 << 60
+
+// PoissonSafe returns a cryptographically secure Poisson-distributed count
+// with mean lambda, such as the number of arrivals in a unit of time.
+//
+// Means below 10 are sampled by inversion; larger ones use Hörmann's PTRS
+// (transformed rejection with squeeze), which takes constant expected time
+// however large lambda is.
+//
+// Parameters:
+//   - lambda: the mean, which must be finite, non-negative and at most 2^60
+//
+// Returns:
+//   - A count in [0, math.MaxInt64]; 0 if lambda is 0
+//   - An error wrapping ErrInvalidParameter if lambda is out of its domain,
+//     or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	arrivals, err := rand.PoissonSafe(3.5) // Requests in the next second
+//	if err != nil {
+//		// Handle error
+//	}
+func PoissonSafe(lambda float64) (int64, error) {
+	return defaultGenerator.PoissonSafe(lambda)
+}
+
+// PoissonSafe is like the package-level PoissonSafe but draws from g.
+func (g *Generator) PoissonSafe(lambda float64) (int64, error) {
+	if !isFinite(lambda) || lambda < 0 || lambda > maxPoissonLambda {
+		return 0, invalidParameter("lambda", lambda, "in [0, 2^60]")
+	}
+	if lambda == 0 {
+		return 0, nil
+	}
+	if lambda < 10 {
+		return g.poissonInversion(lambda)
+	}
+	return g.poissonPTRS(lambda)
+}
+
+// Poisson returns a cryptographically secure Poisson-distributed count with
+// mean lambda. It returns 0 if lambda is invalid, matching RangeInt. In strict
+// mode (see SetStrict) it panics if the entropy source fails.
+//
+// Example:
+//
+//	arrivals := rand.Poisson(3.5)
+func Poisson(lambda float64) int64 {
+	return defaultGenerator.Poisson(lambda)
+}
+
+// Poisson is like the package-level Poisson but draws from g.
+func (g *Generator) Poisson(lambda float64) int64 {
+	return orZero(g.PoissonSafe(lambda))
+}
+
+// poissonInversion walks the CDF from 0 with a single uniform draw
+func (g *Generator) poissonInversion(lambda float64) (int64, error) {
+	for {
+		u, err := g.Float64E()
+		if err != nil {
+			return 0, err
+		}
+
+		p := math.Exp(-lambda)
+		var k int64
+		for u > p {
+			u -= p
+			k++
+			p *= lambda / float64(k)
+			if p == 0 {
+				break // Rounding left u beyond the total mass; redraw
+			}
+		}
+		if p > 0 {
+			return k, nil
+		}
+	}
+}
+
+// poissonPTRS implements W. Hörmann, "The transformed rejection method for
+// generating Poisson random variables" (1993)
+func (g *Generator) poissonPTRS(lambda float64) (int64, error) {
+	slam := math.Sqrt(lambda)
+	loglam := math.Log(lambda)
+	b := 0.931 + 2.53*slam
+	a := -0.059 + 0.02483*b
+	invalpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+
+	for {
+		u, err := g.Float64E()
+		if err != nil {
+			return 0, err
+		}
+		v, err := g.Float64E()
+		if err != nil {
+			return 0, err
+		}
+
+		u -= 0.5
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+
+		// Squeeze: accept without evaluating the density
+		if us >= 0.07 && v <= vr {
+			return int64(k), nil
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+
+		lg, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invalpha)-math.Log(a/(us*us)+b) <= -lambda+k*loglam-lg {
+			return int64(k), nil
+		}
+	}
+}
+
+// BinomialSafe returns a cryptographically secure binomially distributed count:
+// the number of successes in n independent trials that each succeed with
+// probability p.
+//
+// When the smaller of n*p and n*(1-p) is at most 30 it uses inversion;
+// otherwise it uses the BTPE algorithm of Kachitvichyanukul and Schmeiser,
+// which takes constant expected time however large n is.
+//
+// Parameters:
+//   - n: the number of trials, which must be non-negative
+//   - p: the success probability, which must be in [0, 1]
+//
+// Returns:
+//   - A count in [0, n]
+//   - An error wrapping ErrInvalidParameter if a parameter is out of its domain,
+//     or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	failures, err := rand.BinomialSafe(1000, 0.02) // Failed requests out of 1000
+//	if err != nil {
+//		// Handle error
+//	}
+func BinomialSafe(n int64, p float64) (int64, error) {
+	return defaultGenerator.BinomialSafe(n, p)
+}
+
+// BinomialSafe is like the package-level BinomialSafe but draws from g.
+func (g *Generator) BinomialSafe(n int64, p float64) (int64, error) {
+	if n < 0 {
+		return 0, invalidParameter("n", float64(n), ">= 0")
+	}
+	if !(p >= 0 && p <= 1) { // Also catches NaN
+		return 0, invalidParameter("p", p, "in [0, 1]")
+	}
+	if n == 0 || p == 0 {
+		return 0, nil
+	}
+	if p == 1 {
+		return n, nil
+	}
+
+	// Sample the rarer outcome and mirror the result
+	r := math.Min(p, 1-p)
+	var k int64
+	var err error
+	if float64(n)*r <= 30 {
+		k, err = g.binomialInversion(n, r)
+	} else {
+		k, err = g.binomialBTPE(n, r)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if p > 0.5 {
+		k = n - k
+	}
+	return k, nil
+}
+
+// Binomial returns a cryptographically secure binomially distributed count
+// of successes in n trials with success probability p. It returns 0 if a
+// parameter is invalid, matching RangeInt. In strict mode (see SetStrict) it
+// panics if the entropy source fails.
+//
+// Example:
+//
+//	heads := rand.Binomial(100, 0.5)
+func Binomial(n int64, p float64) int64 {
+	return defaultGenerator.Binomial(n, p)
+}
+
+// Binomial is like the package-level Binomial but draws from g.
+func (g *Generator) Binomial(n int64, p float64) int64 {
+	return orZero(g.BinomialSafe(n, p))
+}
+
+// binomialInversion walks the CDF from 0 for p <= 0.5 and n*p <= 30
+func (g *Generator) binomialInversion(n int64, p float64) (int64, error) {
+	q := 1 - p
+	qn := math.Exp(float64(n) * math.Log1p(-p))
+	np := float64(n) * p
+	bound := math.Min(float64(n), np+10*math.Sqrt(np*q+1))
+
+	for {
+		u, err := g.Float64E()
+		if err != nil {
+			return 0, err
+		}
+
+		px := qn
+		var x int64
+		for u > px {
+			x++
+			if float64(x) > bound {
+				break // Far beyond the bulk of the mass; redraw
+			}
+			u -= px
+			px *= float64(n-x+1) * p / (float64(x) * q)
+		}
+		if float64(x) <= bound {
+			return x, nil
+		}
+	}
+}
+
+// binomialBTPE implements V. Kachitvichyanukul and B. Schmeiser, "Binomial
+// random variate generation" (1988), for p <= 0.5 and n*p > 30
+func (g *Generator) binomialBTPE(n int64, p float64) (int64, error) {
+	nf := float64(n)
+	q := 1 - p
+	nrq := nf * p * q
+	fm := nf*p + p
+	m := math.Floor(fm)
+
+	// Setup: a triangle in the middle, parallelograms beside it and
+	// exponential tails on both sides
+	p1 := math.Floor(2.195*math.Sqrt(nrq)-4.6*q) + 0.5
+	xm := m + 0.5
+	xl := xm - p1
+	xr := xm + p1
+	c := 0.134 + 20.5/(15.3+m)
+	a := (fm - xl) / (fm - xl*p)
+	laml := a * (1 + a/2)
+	a = (xr - fm) / (xr * q)
+	lamr := a * (1 + a/2)
+	p2 := p1 * (1 + 2*c)
+	p3 := p2 + c/laml
+	p4 := p3 + c/lamr
+
+	for {
+		u, err := g.Float64E()
+		if err != nil {
+			return 0, err
+		}
+		v, err := g.Float64E()
+		if err != nil {
+			return 0, err
+		}
+		u *= p4
+
+		var y float64
+		switch {
+		case u <= p1:
+			// Triangular region: accept immediately
+			return int64(math.Floor(xm - p1*v + u)), nil
+		case u <= p2:
+			// Parallelograms
+			x := xl + (u-p1)/c
+			v = v*c + 1 - math.Abs(m-x+0.5)/p1
+			if v > 1 {
+				continue
+			}
+			y = math.Floor(x)
+		case u <= p3:
+			// Left exponential tail
+			y = math.Floor(xl + math.Log(v)/laml)
+			if y < 0 || v == 0 {
+				continue
+			}
+			v *= (u - p2) * laml
+		default:
+			// Right exponential tail
+			y = math.Floor(xr - math.Log(v)/lamr)
+			if y > nf || v == 0 {
+				continue
+			}
+			v *= (u - p3) * lamr
+		}
+
+		k := math.Abs(y - m)
+		if k <= 20 || k >= nrq/2-1 {
+			// Evaluate f(y)/f(m) by its recurrence
+			s := p / q
+			a := s * (nf + 1)
+			f := 1.0
+			if m < y {
+				for i := m + 1; i <= y; i++ {
+					f *= a/i - s
+				}
+			} else if m > y {
+				for i := y + 1; i <= m; i++ {
+					f /= a/i - s
+				}
+			}
+			if v <= f {
+				return int64(y), nil
+			}
+			continue
+		}
+
+		// Squeeze on log(f(y)/f(m)) before the Stirling-based bound
+		rho := (k / nrq) * ((k*(k/3+0.625)+1.0/6)/nrq + 0.5)
+		t := -k * k / (2 * nrq)
+		alpha := math.Log(v)
+		if alpha < t-rho {
+			return int64(y), nil
+		}
+		if alpha > t+rho {
+			continue
+		}
+
+		x1 := y + 1
+		f1 := m + 1
+		z := nf + 1 - m
+		w := nf - y + 1
+		// The factorials of m and n-m are in the numerator and those of y and
+		// n-y in the denominator, so their Stirling corrections enter with
+		// opposite signs
+		bound := xm*math.Log(f1/x1) + (nf-m+0.5)*math.Log(z/w) + (y-m)*math.Log(w*p/(x1*q)) +
+			stirlingCorrection(f1) + stirlingCorrection(z) - stirlingCorrection(x1) - stirlingCorrection(w)
+		if alpha <= bound {
+			return int64(y), nil
+		}
+	}
+}
+
+// stirlingCorrection returns the remainder log Γ(x) - ((x-0.5) log x - x + log(2π)/2)
+// of Stirling's approximation, accurate for x >= 10
+func stirlingCorrection(x float64) float64 {
+	x2 := x * x
+	return (13860 - (462-(132-(99-140/x2)/x2)/x2)/x2) / x / 166320
+}
+
+// GeometricSafe returns a cryptographically secure geometrically distributed
+// count: the number of independent trials, each succeeding with probability p,
+// up to and including the first success. The result is at least 1 and has
+// mean 1/p.
+//
+// Parameters:
+//   - p: the success probability, which must be in (0, 1]
+//
+// Returns:
+//   - A count in [1, math.MaxInt64], saturating for extremely small p
+//   - An error wrapping ErrInvalidParameter if p is out of its domain,
+//     or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	attempts, err := rand.GeometricSafe(0.3) // Attempts until a retry succeeds
+//	if err != nil {
+//		// Handle error
+//	}
+func GeometricSafe(p float64) (int64, error) {
+	return defaultGenerator.GeometricSafe(p)
+}
+
+// GeometricSafe is like the package-level GeometricSafe but draws from g.
+func (g *Generator) GeometricSafe(p float64) (int64, error) {
+	if !(p > 0 && p <= 1) { // Also catches NaN
+		return 0, invalidParameter("p", p, "in (0, 1]")
+	}
+	if p == 1 {
+		return 1, nil
+	}
+
+	// Inversion: P(X > k) = (1-p)^k
+	u, err := g.openFloat64()
+	if err != nil {
+		return 0, err
+	}
+	k := math.Floor(math.Log(u)/math.Log1p(-p)) + 1
+	if k >= math.MaxInt64 {
+		return math.MaxInt64, nil
+	}
+	return int64(k), nil
+}
+
+// Geometric returns a cryptographically secure geometrically distributed
+// number of trials up to and including the first success. It returns 0 if p
+// is invalid, matching RangeInt. In strict mode (see SetStrict) it panics if
+// the entropy source fails.
+//
+// Example:
+//
+//	attempts := rand.Geometric(0.3)
+func Geometric(p float64) int64 {
+	return defaultGenerator.Geometric(p)
+}
+
+// Geometric is like the package-level Geometric but draws from g.
+func (g *Generator) Geometric(p float64) int64 {
+	return orZero(g.GeometricSafe(p))
+}
+
+// HypergeometricSafe returns a cryptographically secure hypergeometrically
+// distributed count: the number of successes among draws items drawn without
+// replacement from a population of total items, of which successes are
+// successes.
+//
+// Small samples are drawn one by one; larger ones use Stadlober's ratio of
+// uniforms method (HRUA), which takes constant expected time.
+//
+// Parameters:
+//   - total: the population size, which must be non-negative
+//   - successes: the number of successes in the population, in [0, total]
+//   - draws: the number of items drawn, in [0, total]
+//
+// Returns:
+//   - A count in [max(0, draws+successes-total), min(draws, successes)]
+//   - An error wrapping ErrInvalidParameter if a parameter is out of its domain,
+//     or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	// Defective parts in a sample of 50 from a lot of 1000 with 30 defects
+//	defects, err := rand.HypergeometricSafe(1000, 30, 50)
+//	if err != nil {
+//		// Handle error
+//	}
+func HypergeometricSafe(total, successes, draws int64) (int64, error) {
+	return defaultGenerator.HypergeometricSafe(total, successes, draws)
+}
+
+// HypergeometricSafe is like the package-level HypergeometricSafe but draws from g.
+func (g *Generator) HypergeometricSafe(total, successes, draws int64) (int64, error) {
+	if total < 0 {
+		return 0, invalidParameter("total", float64(total), ">= 0")
+	}
+	if successes < 0 || successes > total {
+		return 0, invalidParameter("successes", float64(successes), "in [0, total]")
+	}
+	if draws < 0 || draws > total {
+		return 0, invalidParameter("draws", float64(draws), "in [0, total]")
+	}
+
+	failures := total - successes
+	if draws >= 10 && draws <= total-10 {
+		return g.hypergeometricHRUA(successes, failures, draws)
+	}
+	return g.hypergeometricUrn(successes, failures, draws)
+}
+
+// Hypergeometric returns a cryptographically secure hypergeometrically
+// distributed count of successes among draws items drawn without replacement.
+// It returns 0 if a parameter is invalid, matching RangeInt. In strict mode
+// (see SetStrict) it panics if the entropy source fails.
+//
+// Example:
+//
+//	defects := rand.Hypergeometric(1000, 30, 50)
+func Hypergeometric(total, successes, draws int64) int64 {
+	return defaultGenerator.Hypergeometric(total, successes, draws)
+}
+
+// Hypergeometric is like the package-level Hypergeometric but draws from g.
+func (g *Generator) Hypergeometric(total, successes, draws int64) int64 {
+	return orZero(g.HypergeometricSafe(total, successes, draws))
+}
+
+// hypergeometricUrn simulates the draws one at a time. It draws the smaller of
+// the sample and its complement, so it needs at most 10 random numbers here.
+func (g *Generator) hypergeometricUrn(good, bad, sample int64) (int64, error) {
+	total := good + bad
+	selected := sample
+	if sample > total/2 {
+		selected = total - sample
+	}
+
+	remainingTotal, remainingGood := total, good
+	for selected > 0 && remainingGood > 0 && remainingGood < remainingTotal {
+		r, err := g.uint64n(uint64(remainingTotal))
+		if err != nil {
+			return 0, err
+		}
+		if r < uint64(remainingGood) {
+			remainingGood--
+		}
+		remainingTotal--
+		selected--
+	}
+	if remainingGood == remainingTotal {
+		remainingGood -= selected // Only successes are left
+	}
+
+	if sample > total/2 {
+		return remainingGood, nil
+	}
+	return good - remainingGood, nil
+}
+
+// hypergeometricHRUA implements E. Stadlober, "The ratio of uniforms approach
+// for generating discrete random variates" (1990)
+func (g *Generator) hypergeometricHRUA(good, bad, sample int64) (int64, error) {
+	const (
+		d1 = 1.7155277699214135 // 2*sqrt(2/e)
+		d2 = 0.8989161620588988 // 3 - 2*sqrt(3/e)
+	)
+
+	total := good + bad
+	computed := sample
+	if total-sample < sample {
+		computed = total - sample
+	}
+	minGoodBad, maxGoodBad := good, bad
+	if bad < good {
+		minGoodBad, maxGoodBad = bad, good
+	}
+
+	nf := float64(total)
+	p := float64(minGoodBad) / nf
+	q := float64(maxGoodBad) / nf
+	mu := float64(computed) * p
+	a := mu + 0.5
+	variance := float64(total-computed) * float64(computed) * p * q / (nf - 1)
+	c := math.Sqrt(variance + 0.5)
+	h := d1*c + d2
+
+	// m is the mode; g is the log density there, up to a constant
+	m := math.Floor(float64(computed+1) * float64(minGoodBad+1) / (nf + 2))
+	logDensity := func(k float64) float64 {
+		return logFactorial(k) + logFactorial(float64(minGoodBad)-k) +
+			logFactorial(float64(computed)-k) + logFactorial(float64(maxGoodBad-computed)+k)
+	}
+	gm := logDensity(m)
+	b := math.Min(math.Min(float64(computed), float64(minGoodBad))+1, math.Floor(a+16*c))
+
+	var k float64
+	for {
+		u, err := g.openFloat64()
+		if err != nil {
+			return 0, err
+		}
+		v, err := g.Float64E()
+		if err != nil {
+			return 0, err
+		}
+
+		x := a + h*(v-0.5)/u
+		if x < 0 || x >= b {
+			continue
+		}
+		k = math.Floor(x)
+
+		t := gm - logDensity(k)
+		if u*(4-u)-3 <= t {
+			break // Fast acceptance
+		}
+		if u*(u-t) >= 1 {
+			continue // Fast rejection
+		}
+		if 2*math.Log(u) <= t {
+			break
+		}
+	}
+
+	result := int64(k)
+	if good > bad {
+		result = computed - result
+	}
+	if computed < sample {
+		result = good - result
+	}
+	return result, nil
+}
+
+// logFactorial returns log(k!) for a non-negative integer k
+func logFactorial(k float64) float64 {
+	lg, _ := math.Lgamma(k + 1)
+	return lg
+}
@@ -0,0 +1,244 @@
+package rand
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chiSquareGOF tests observed counts of n samples against the probability mass
+// function pmf. Values in [lo, hi] with an expected count of at least 5 get their
+// own bin; everything else is pooled into one bin. It returns the statistic and
+// the critical value at a significance level of 0.0001.
+func chiSquareGOF(counts map[int64]int, n int, lo, hi int64, pmf func(int64) float64) (stat, critical float64) {
+	pooledObserved, pooledExpected := float64(n), float64(n)
+	bins := 0
+
+	for k := lo; k <= hi; k++ {
+		expected := float64(n) * pmf(k)
+		if expected < 5 {
+			continue
+		}
+		observed := float64(counts[k])
+		stat += (observed - expected) * (observed - expected) / expected
+		pooledObserved -= observed
+		pooledExpected -= expected
+		bins++
+	}
+
+	if pooledExpected >= 1 {
+		stat += (pooledObserved - pooledExpected) * (pooledObserved - pooledExpected) / pooledExpected
+		bins++
+	}
+
+	// Wilson–Hilferty approximation of the chi-squared quantile
+	df := float64(bins - 1)
+	const z = 3.719
+	critical = df * math.Pow(1-2/(9*df)+z*math.Sqrt(2/(9*df)), 3)
+	return stat, critical
+}
+
+// logChoose returns log(n choose k)
+func logChoose(n, k int64) float64 {
+	return logFactorial(float64(n)) - logFactorial(float64(k)) - logFactorial(float64(n-k))
+}
+
+// countDraws collects n samples from draw into a histogram
+func countDraws(n int, draw func() int64) map[int64]int {
+	counts := make(map[int64]int)
+	for i := 0; i < n; i++ {
+		counts[draw()]++
+	}
+	return counts
+}
+
+// TestPoisson validates both sampling methods against the exact pmf
+func TestPoisson(t *testing.T) {
+	const n = 100000
+
+	for _, lambda := range []float64{0.5, 3, 9.9, 10, 47.5, 1000} {
+		g := NewSeeded(uint64(lambda * 10))
+		counts := countDraws(n, func() int64 { return g.Poisson(lambda) })
+
+		pmf := func(k int64) float64 {
+			return math.Exp(float64(k)*math.Log(lambda) - lambda - logFactorial(float64(k)))
+		}
+		spread := int64(10*math.Sqrt(lambda)) + 10
+		lo := int64(math.Max(0, lambda-float64(spread)))
+		stat, critical := chiSquareGOF(counts, n, lo, int64(lambda)+spread, pmf)
+		assert.Less(t, stat, critical, "Poisson(%g) failed the chi-squared test", lambda)
+	}
+
+	// Huge means stay centred
+	var sum float64
+	for i := 0; i < 1000; i++ {
+		sum += float64(Poisson(1e15))
+	}
+	assert.InDelta(t, 1e15, sum/1000, 1e15*1e-6)
+
+	assert.Equal(t, int64(0), Poisson(0))
+	for _, lambda := range []float64{-1, math.NaN(), math.Inf(1), 1 << 61} {
+		_, err := PoissonSafe(lambda)
+		assert.ErrorIs(t, err, ErrInvalidParameter, "PoissonSafe(%g)", lambda)
+	}
+}
+
+// TestBinomial validates inversion and BTPE against the exact pmf
+func TestBinomial(t *testing.T) {
+	const n = 100000
+
+	testCases := []struct {
+		trials int64
+		p      float64
+	}{
+		{10, 0.3},
+		{100, 0.2},     // Inversion at n*p = 20
+		{100, 0.9},     // Mirrored
+		{200, 0.4},     // BTPE
+		{5000, 0.5},    // BTPE near the squeeze
+		{100000, 0.01}, // Inversion with a large n
+		{1000000, 0.3}, // BTPE with a large n
+	}
+
+	for _, tc := range testCases {
+		g := NewSeeded(uint64(tc.trials))
+		counts := countDraws(n, func() int64 { return g.Binomial(tc.trials, tc.p) })
+
+		pmf := func(k int64) float64 {
+			if k < 0 || k > tc.trials {
+				return 0
+			}
+			return math.Exp(logChoose(tc.trials, k) + float64(k)*math.Log(tc.p) + float64(tc.trials-k)*math.Log1p(-tc.p))
+		}
+		mean := float64(tc.trials) * tc.p
+		spread := 10*math.Sqrt(mean*(1-tc.p)) + 10
+		lo := int64(math.Max(0, mean-spread))
+		hi := int64(math.Min(float64(tc.trials), mean+spread))
+		stat, critical := chiSquareGOF(counts, n, lo, hi, pmf)
+		assert.Less(t, stat, critical, "Binomial(%d, %g) failed the chi-squared test", tc.trials, tc.p)
+
+		for k := range counts {
+			require.True(t, k >= 0 && k <= tc.trials, "Binomial(%d, %g) returned %d", tc.trials, tc.p, k)
+		}
+	}
+
+	assert.Equal(t, int64(0), Binomial(0, 0.5))
+	assert.Equal(t, int64(0), Binomial(10, 0))
+	assert.Equal(t, int64(10), Binomial(10, 1))
+	for _, tc := range []struct {
+		n int64
+		p float64
+	}{{-1, 0.5}, {10, -0.1}, {10, 1.1}, {10, math.NaN()}} {
+		_, err := BinomialSafe(tc.n, tc.p)
+		assert.ErrorIs(t, err, ErrInvalidParameter, "BinomialSafe(%d, %g)", tc.n, tc.p)
+	}
+}
+
+// TestGeometric validates the geometric distribution against the exact pmf
+func TestGeometric(t *testing.T) {
+	const n = 100000
+
+	for _, p := range []float64{0.9, 0.3, 0.01} {
+		counts := countDraws(n, func() int64 { return Geometric(p) })
+		pmf := func(k int64) float64 {
+			return math.Pow(1-p, float64(k-1)) * p
+		}
+		stat, critical := chiSquareGOF(counts, n, 1, int64(20/p), pmf)
+		assert.Less(t, stat, critical, "Geometric(%g) failed the chi-squared test", p)
+		assert.Zero(t, counts[0], "Geometric counts trials, so it is at least 1")
+	}
+
+	assert.Equal(t, int64(1), Geometric(1))
+	assert.Greater(t, Geometric(1e-300), int64(1e15))
+	for _, p := range []float64{0, -0.5, 1.5, math.NaN()} {
+		_, err := GeometricSafe(p)
+		assert.ErrorIs(t, err, ErrInvalidParameter, "GeometricSafe(%g)", p)
+	}
+}
+
+// TestHypergeometric validates both sampling methods against the exact pmf
+func TestHypergeometric(t *testing.T) {
+	const n = 100000
+
+	testCases := [][3]int64{
+		{20, 7, 5},             // Urn
+		{20, 7, 15},            // Urn via the complement
+		{1000, 30, 50},         // HRUA
+		{1000, 700, 400},       // HRUA with more successes than failures
+		{100000, 40000, 90000}, // HRUA via the complement
+	}
+
+	for _, tc := range testCases {
+		total, successes, draws := tc[0], tc[1], tc[2]
+		g := NewSeeded(uint64(total + draws))
+		counts := countDraws(n, func() int64 { return g.Hypergeometric(total, successes, draws) })
+
+		lo := int64(math.Max(0, float64(draws+successes-total)))
+		hi := int64(math.Min(float64(draws), float64(successes)))
+		pmf := func(k int64) float64 {
+			if k < lo || k > hi {
+				return 0
+			}
+			return math.Exp(logChoose(successes, k) + logChoose(total-successes, draws-k) - logChoose(total, draws))
+		}
+		stat, critical := chiSquareGOF(counts, n, lo, hi, pmf)
+		assert.Less(t, stat, critical, "Hypergeometric(%d, %d, %d) failed the chi-squared test", total, successes, draws)
+		for k := range counts {
+			require.True(t, k >= lo && k <= hi, "Hypergeometric(%d, %d, %d) returned %d", total, successes, draws, k)
+		}
+	}
+
+	assert.Equal(t, int64(3), Hypergeometric(10, 10, 3))
+	assert.Equal(t, int64(0), Hypergeometric(10, 0, 3))
+	assert.Equal(t, int64(4), Hypergeometric(10, 4, 10))
+	assert.Equal(t, int64(0), Hypergeometric(0, 0, 0))
+
+	for _, tc := range [][3]int64{{-1, 0, 0}, {10, 11, 5}, {10, -1, 5}, {10, 5, 11}, {10, 5, -1}} {
+		_, err := HypergeometricSafe(tc[0], tc[1], tc[2])
+		assert.ErrorIs(t, err, ErrInvalidParameter, "HypergeometricSafe%v", tc)
+	}
+}
+
+// TestDiscreteGenerator validates seeded and strict generators
+func TestDiscreteGenerator(t *testing.T) {
+	a, b := NewSeeded(8), NewSeeded(8)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, a.Poisson(50), b.Poisson(50))
+		assert.Equal(t, a.Binomial(1000, 0.4), b.Binomial(1000, 0.4))
+		assert.Equal(t, a.Geometric(0.2), b.Geometric(0.2))
+		assert.Equal(t, a.Hypergeometric(500, 200, 100), b.Hypergeometric(500, 200, 100))
+	}
+
+	strict := NewGenerator(failingSource{}, WithStrict())
+	_, err := strict.PoissonSafe(3)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = strict.BinomialSafe(100, 0.5)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = strict.GeometricSafe(0.5)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = strict.HypergeometricSafe(100, 50, 20)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	assert.Panics(t, func() { strict.Poisson(100) })
+	assert.Equal(t, int64(0), strict.Binomial(-1, 0.5))
+}
+
+// BenchmarkDiscrete benchmarks the discrete samplers with large parameters
+func BenchmarkDiscrete(b *testing.B) {
+	b.Run("Poisson", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = Poisson(1000)
+		}
+	})
+	b.Run("Binomial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = Binomial(1000000, 0.3)
+		}
+	})
+	b.Run("Hypergeometric", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = Hypergeometric(100000, 40000, 5000)
+		}
+	})
+}
@@ -0,0 +1,176 @@
+package rand
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ksStatistic returns the Kolmogorov–Smirnov distance between the empirical
+// distribution of samples and the continuous CDF cdf. It sorts samples.
+func ksStatistic(samples []float64, cdf func(float64) float64) float64 {
+	sort.Float64s(samples)
+	n := float64(len(samples))
+
+	var d float64
+	for i, x := range samples {
+		f := cdf(x)
+		d = math.Max(d, math.Max(f-float64(i)/n, float64(i+1)/n-f))
+	}
+	return d
+}
+
+// ksCritical is the KS critical value for n samples at a significance level
+// of 1e-6, sqrt(-ln(α/2)/2)/sqrt(n): low enough that the suite's dozens of KS
+// checks do not fail by chance, while the sample sizes still expose real bias
+func ksCritical(n int) float64 {
+	return 2.69 / math.Sqrt(float64(n))
+}
+
+// normalCDF is the CDF of the normal distribution
+func normalCDF(mean, stddev float64) func(float64) float64 {
+	return func(x float64) float64 {
+		return 0.5 * math.Erfc(-(x-mean)/(stddev*math.Sqrt2))
+	}
+}
+
+// drawN collects n samples from draw
+func drawN(n int, draw func() float64) []float64 {
+	samples := make([]float64, n)
+	for i := range samples {
+		samples[i] = draw()
+	}
+	return samples
+}
+
+// TestNormFloat64 validates the shape of the normal distribution with a KS test
+func TestNormFloat64(t *testing.T) {
+	const n = 200000
+
+	samples := drawN(n, NormFloat64)
+
+	// Count the tails before sorting; the base layer's tail starts at 3.44
+	var beyond2, beyondTail int
+	for _, z := range samples {
+		if math.Abs(z) > 2 {
+			beyond2++
+		}
+		if math.Abs(z) > zigNormR {
+			beyondTail++
+		}
+	}
+	// Both counts are close to Poisson; allow five standard deviations
+	expected2, expectedTail := n*math.Erfc(2/math.Sqrt2), n*math.Erfc(zigNormR/math.Sqrt2)
+	assert.InDelta(t, expected2, beyond2, 5*math.Sqrt(expected2), "P(|Z| > 2)")
+	assert.InDelta(t, expectedTail, beyondTail, 5*math.Sqrt(expectedTail), "P(|Z| > r)")
+
+	d := ksStatistic(samples, normalCDF(0, 1))
+	assert.Less(t, d, ksCritical(n), "NormFloat64 failed the KS test")
+}
+
+// TestNormal validates mean and standard deviation scaling
+func TestNormal(t *testing.T) {
+	const n = 50000
+
+	samples := drawN(n, func() float64 { return Normal(100, 15) })
+	var sum, sumSq float64
+	for _, x := range samples {
+		sum += x
+		sumSq += x * x
+	}
+	mean := sum / n
+	assert.InDelta(t, 100, mean, 0.3)
+	assert.InDelta(t, 15, math.Sqrt(sumSq/n-mean*mean), 0.3)
+	assert.Less(t, ksStatistic(samples, normalCDF(100, 15)), ksCritical(n))
+
+	assert.Equal(t, 7.0, Normal(7, 0))
+
+	for _, tc := range [][2]float64{{0, -1}, {math.NaN(), 1}, {0, math.Inf(1)}, {math.Inf(-1), 1}} {
+		_, err := NormalSafe(tc[0], tc[1])
+		assert.ErrorIs(t, err, ErrInvalidParameter, "NormalSafe(%g, %g)", tc[0], tc[1])
+		assert.Equal(t, 0.0, Normal(tc[0], tc[1]))
+	}
+}
+
+// TestExpFloat64 validates the shape of the exponential distribution with a KS test
+func TestExpFloat64(t *testing.T) {
+	const n = 200000
+
+	for _, rate := range []float64{1, 0.25, 40} {
+		samples := drawN(n, func() float64 { return ExpFloat64(rate) })
+
+		var tail int
+		for _, x := range samples {
+			require.GreaterOrEqual(t, x, 0.0)
+			if x*rate > zigExpR {
+				tail++
+			}
+		}
+		// As in TestNormFloat64, allow five standard deviations of the count
+		expected := n * math.Exp(-zigExpR)
+		assert.InDelta(t, expected, tail, 5*math.Sqrt(expected), "rate %g: P(X > r)", rate)
+
+		d := ksStatistic(samples, func(x float64) float64 { return -math.Expm1(-rate * x) })
+		assert.Less(t, d, ksCritical(n), "ExpFloat64(%g) failed the KS test", rate)
+	}
+
+	for _, rate := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		_, err := ExpFloat64Safe(rate)
+		assert.ErrorIs(t, err, ErrInvalidParameter, "ExpFloat64Safe(%g)", rate)
+		assert.Equal(t, 0.0, ExpFloat64(rate))
+	}
+}
+
+// TestZigguratTables validates that the layers tile the densities
+func TestZigguratTables(t *testing.T) {
+	// Layer edges shrink towards the peak and the top layer has no inner rectangle
+	assert.Equal(t, uint64(0), zigNorm.k[1])
+	assert.Equal(t, uint64(0), zigExp.k[1])
+	for i := 2; i < zigNormLayers; i++ {
+		assert.Less(t, zigNorm.w[i-1], zigNorm.w[i], "normal layer %d", i)
+		assert.Less(t, zigNorm.k[i], uint64(zigScale), "normal layer %d", i)
+	}
+	for i := 2; i < zigExpLayers; i++ {
+		assert.Less(t, zigExp.w[i-1], zigExp.w[i], "exponential layer %d", i)
+	}
+	assert.InDelta(t, 1.0, zigNorm.f[0], 0)
+	assert.InDelta(t, math.Exp(-0.5*zigNormR*zigNormR), zigNorm.f[zigNormLayers-1], 1e-15)
+}
+
+// TestDistributionsGenerator validates seeded and strict generators
+func TestDistributionsGenerator(t *testing.T) {
+	a, b := NewSeeded(4), NewSeeded(4)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, a.NormFloat64(), b.NormFloat64())
+		assert.Equal(t, a.ExpFloat64(2), b.ExpFloat64(2))
+	}
+
+	strict := NewGenerator(failingSource{}, WithStrict())
+	_, err := strict.NormFloat64E()
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = strict.NormalSafe(0, 1)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = strict.ExpFloat64Safe(1)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	assert.Panics(t, func() { strict.Normal(0, 1) })
+	assert.Equal(t, 0.0, strict.ExpFloat64(-1), "invalid parameters are reported before drawing")
+}
+
+// BenchmarkNormFloat64 benchmarks the NormFloat64 function
+func BenchmarkNormFloat64(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = NormFloat64()
+	}
+}
+
+// BenchmarkExpFloat64 benchmarks the ExpFloat64 function
+func BenchmarkExpFloat64(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = ExpFloat64(1)
+	}
+}
@@ -0,0 +1,187 @@
+package rand
+
+import (
+	cRand "crypto/rand"
+	"encoding/binary"
+	"io"
+	"math/bits"
+	"sync"
+)
+
+const (
+	// chachaBlocks is the number of ChaCha20 blocks generated per refill
+	chachaBlocks = 16
+
+	// shardBufSize is the keystream buffer of a shard; its first chachaKeySize
+	// bytes become the next key and are never handed out
+	shardBufSize = chachaBlocks * 64
+
+	// chachaKeySize is the size of a ChaCha20 key in bytes
+	chachaKeySize = 32
+)
+
+// entropyPool is a buffered, sharded source of cryptographically secure bytes.
+//
+// Each shard runs a ChaCha20 keystream in fast-key-erasure mode: every refill
+// produces a buffer of keystream, the first 32 bytes immediately replace the
+// key and the rest is handed out, with every byte zeroed once used. A compromise
+// of a shard's state therefore never reveals earlier output. Shards are seeded
+// from crypto/rand and kept in a sync.Pool, which keeps them per-P so that
+// parallel callers rarely contend and make one syscall-free read per 992 bytes.
+type entropyPool struct {
+	shards sync.Pool
+	seed   io.Reader // source of shard keys, normally crypto/rand.Reader
+}
+
+// entropyShard is the per-goroutine state of an entropyPool
+type entropyShard struct {
+	key [8]uint32
+	buf [shardBufSize]byte
+	pos int // next unread byte of buf
+}
+
+// defaultEntropyPool backs the default Generator
+var defaultEntropyPool = newEntropyPool(cRand.Reader)
+
+// newEntropyPool returns a pool whose shards are keyed from seed
+func newEntropyPool(seed io.Reader) *entropyPool {
+	return &entropyPool{seed: seed}
+}
+
+// getShard returns a shard for exclusive use, seeding a new one if needed
+func (p *entropyPool) getShard() (*entropyShard, error) {
+	if s, ok := p.shards.Get().(*entropyShard); ok {
+		return s, nil
+	}
+
+	s := new(entropyShard)
+	if _, err := io.ReadFull(p.seed, s.buf[:chachaKeySize]); err != nil {
+		return nil, err
+	}
+	s.rekey()
+	s.refill()
+	return s, nil
+}
+
+// Read fills b with keystream bytes. It only fails if a new shard cannot be seeded.
+func (p *entropyPool) Read(b []byte) (int, error) {
+	s, err := p.getShard()
+	if err != nil {
+		return 0, err
+	}
+
+	n := len(b)
+	for len(b) > 0 {
+		if s.pos == shardBufSize {
+			s.refill()
+		}
+		avail := s.buf[s.pos:]
+		copied := copy(b, avail)
+		zero(avail[:copied])
+		s.pos += copied
+		b = b[copied:]
+	}
+
+	p.shards.Put(s)
+	return n, nil
+}
+
+// uint64 returns 64 bits of keystream without going through a byte slice
+func (p *entropyPool) uint64() (uint64, error) {
+	s, err := p.getShard()
+	if err != nil {
+		return 0, err
+	}
+
+	if s.pos+8 > shardBufSize {
+		s.refill()
+	}
+	n := binary.LittleEndian.Uint64(s.buf[s.pos:])
+	zero(s.buf[s.pos : s.pos+8])
+	s.pos += 8
+
+	p.shards.Put(s)
+	return n, nil
+}
+
+// refill generates a fresh keystream buffer and immediately replaces the key
+// with its first 32 bytes
+func (s *entropyShard) refill() {
+	for i := 0; i < chachaBlocks; i++ {
+		chachaBlock((*[64]byte)(s.buf[i*64:]), &s.key, uint32(i))
+	}
+	s.rekey()
+}
+
+// rekey loads the key from the start of buf and erases it there
+func (s *entropyShard) rekey() {
+	for i := range s.key {
+		s.key[i] = binary.LittleEndian.Uint32(s.buf[i*4:])
+	}
+	zero(s.buf[:chachaKeySize])
+	s.pos = chachaKeySize
+}
+
+// zero overwrites b with zeros
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// chachaBlock writes the ChaCha20 block (RFC 8439) for key, counter and an
+// all-zero nonce to out. Every shard uses its key only once per counter value.
+func chachaBlock(out *[64]byte, key *[8]uint32, counter uint32) {
+	const (
+		c0 = 0x61707865 // "expand 32-byte k"
+		c1 = 0x3320646e
+		c2 = 0x79622d32
+		c3 = 0x6b206574
+	)
+
+	x0, x1, x2, x3 := uint32(c0), uint32(c1), uint32(c2), uint32(c3)
+	x4, x5, x6, x7 := key[0], key[1], key[2], key[3]
+	x8, x9, x10, x11 := key[4], key[5], key[6], key[7]
+	x12, x13, x14, x15 := counter, uint32(0), uint32(0), uint32(0)
+
+	for i := 0; i < 10; i++ {
+		// Column rounds
+		x0, x4, x8, x12 = quarterRound(x0, x4, x8, x12)
+		x1, x5, x9, x13 = quarterRound(x1, x5, x9, x13)
+		x2, x6, x10, x14 = quarterRound(x2, x6, x10, x14)
+		x3, x7, x11, x15 = quarterRound(x3, x7, x11, x15)
+
+		// Diagonal rounds
+		x0, x5, x10, x15 = quarterRound(x0, x5, x10, x15)
+		x1, x6, x11, x12 = quarterRound(x1, x6, x11, x12)
+		x2, x7, x8, x13 = quarterRound(x2, x7, x8, x13)
+		x3, x4, x9, x14 = quarterRound(x3, x4, x9, x14)
+	}
+
+	words := [16]uint32{
+		x0 + c0, x1 + c1, x2 + c2, x3 + c3,
+		x4 + key[0], x5 + key[1], x6 + key[2], x7 + key[3],
+		x8 + key[4], x9 + key[5], x10 + key[6], x11 + key[7],
+		x12 + counter, x13, x14, x15,
+	}
+	for i, w := range words {
+		binary.LittleEndian.PutUint32(out[i*4:], w)
+	}
+}
+
+// quarterRound is the ChaCha quarter round
+func quarterRound(a, b, c, d uint32) (uint32, uint32, uint32, uint32) {
+	a += b
+	d ^= a
+	d = bits.RotateLeft32(d, 16)
+	c += d
+	b ^= c
+	b = bits.RotateLeft32(b, 12)
+	a += b
+	d ^= a
+	d = bits.RotateLeft32(d, 8)
+	c += d
+	b ^= c
+	b = bits.RotateLeft32(b, 7)
+	return a, b, c, d
+}
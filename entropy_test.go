@@ -0,0 +1,165 @@
+package rand
+
+import (
+	cRand "crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestChachaBlock validates the block function against the RFC 8439 test vectors (A.1)
+func TestChachaBlock(t *testing.T) {
+	testCases := []struct {
+		counter uint32
+		want    string
+	}{
+		{0, "76b8e0ada0f13d90405d6ae55386bd28bdd219b8a08ded1aa836efcc8b770dc7" +
+			"da41597c5157488d7724e03fb8d84a376a43b8f41518a11cc387b669b2ee6586"},
+		{1, "9f07e7be5551387a98ba977c732d080dcb0f29a048e3656912c6533e32ee7aed" +
+			"29b721769ce64e43d57133b074d839d531ed1f28510afb45ace10a1f4b794d6f"},
+	}
+
+	var key [8]uint32
+	for _, tc := range testCases {
+		var out [64]byte
+		chachaBlock(&out, &key, tc.counter)
+		assert.Equal(t, tc.want, hex.EncodeToString(out[:]), "block %d", tc.counter)
+	}
+}
+
+// TestEntropyPool validates reads from the buffered pool
+func TestEntropyPool(t *testing.T) {
+	pool := newEntropyPool(cRand.Reader)
+
+	// Reads spanning several refills should succeed and not repeat
+	seen := make(map[string]bool)
+	for _, size := range []int{1, 7, 8, 100, shardBufSize, 3 * shardBufSize} {
+		buf := make([]byte, size)
+		n, err := pool.Read(buf)
+		require.NoError(t, err)
+		assert.Equal(t, size, n)
+
+		if size >= 8 {
+			key := hex.EncodeToString(buf[:8])
+			assert.False(t, seen[key], "pool output repeated")
+			seen[key] = true
+		}
+	}
+
+	values := make(map[uint64]bool)
+	for i := 0; i < 10000; i++ {
+		n, err := pool.uint64()
+		require.NoError(t, err)
+		values[n] = true
+	}
+	assert.Len(t, values, 10000, "pool should not repeat 64-bit values")
+}
+
+// TestEntropyPoolSeedFailure validates that seeding failures are reported to the Generator
+func TestEntropyPoolSeedFailure(t *testing.T) {
+	pool := newEntropyPool(failingSource{})
+
+	_, err := pool.Read(make([]byte, 8))
+	assert.Error(t, err)
+
+	g := NewGenerator(pool, WithStrict())
+	_, err = g.Int64E()
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+}
+
+// TestEntropyPoolErasure validates that consumed keystream is wiped from the shard
+func TestEntropyPoolErasure(t *testing.T) {
+	pool := newEntropyPool(cRand.Reader)
+	s, err := pool.getShard()
+	require.NoError(t, err)
+
+	assert.Equal(t, make([]byte, chachaKeySize), s.buf[:chachaKeySize], "key bytes should be erased")
+
+	pool.shards.Put(s)
+	buf := make([]byte, 64)
+	_, err = pool.Read(buf)
+	require.NoError(t, err)
+
+	// Get may hand back a different shard after a GC; only check when it is the same one
+	if s2, _ := pool.getShard(); s2 == s {
+		assert.Equal(t, make([]byte, s.pos), s.buf[:s.pos], "consumed bytes should be erased")
+	}
+}
+
+// TestEntropyPoolConcurrency validates that parallel readers never share a shard
+func TestEntropyPoolConcurrency(t *testing.T) {
+	const goroutines = 32
+	const iterations = 2000
+
+	var mu sync.Mutex
+	values := make(map[uint64]bool, goroutines*iterations)
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			local := make([]uint64, 0, iterations)
+			for j := 0; j < iterations; j++ {
+				local = append(local, Uint64())
+			}
+			mu.Lock()
+			for _, n := range local {
+				values[n] = true
+			}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, values, goroutines*iterations, "concurrent pool output should not repeat")
+}
+
+// BenchmarkParallelContention compares sources under parallel load
+func BenchmarkParallelContention(b *testing.B) {
+	sources := []struct {
+		name string
+		gen  *Generator
+	}{
+		{"EntropyPool", NewGenerator(nil)},
+		{"CryptoRand", NewGenerator(cRand.Reader)},
+		{"LockedShard", NewGenerator(&lockedShard{pool: newEntropyPool(cRand.Reader)})},
+	}
+
+	for _, src := range sources {
+		g := src.gen
+		b.Run(fmt.Sprintf("Int64-%s", src.name), func(b *testing.B) {
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_ = g.Int64()
+				}
+			})
+		})
+
+		b.Run(fmt.Sprintf("String32-%s", src.name), func(b *testing.B) {
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_ = g.String(32)
+				}
+			})
+		})
+	}
+}
+
+// lockedShard serializes all readers through one pool, approximating an unsharded buffer
+type lockedShard struct {
+	mu   sync.Mutex
+	pool *entropyPool
+}
+
+func (l *lockedShard) Read(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.pool.Read(p)
+}
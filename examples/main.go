@@ -3,7 +3,6 @@ package main
 import (
 	"fmt"
 	"log"
-	"strings"
 
 	rand "github.com/tinystack/tsrand"
 )
@@ -117,31 +116,64 @@ func demonstratePasswordGeneration() {
 	complexPassword := rand.CustomString(complexCharset, 20)
 	fmt.Printf("复杂密码 (20位):     %s\n", complexPassword)
 
-	// 分层密码生成
-	fmt.Println("\n--- 分层密码生成 ---")
-	upper := rand.UppercaseString(4)
-	lower := rand.LowercaseString(4)
-	numbers := rand.NumericString(4)
-	special := rand.CustomString("!@#$%^&*", 2)
+	// 按策略生成密码：在所有满足规则的密码中均匀选取
+	fmt.Println("\n--- 策略密码生成 ---")
+	policy := rand.DefaultPasswordPolicy()
+	policy.Length = 14
+	policy.MinUpper = 4
+	policy.MinDigits = 4
+	policy.MinSymbols = 2
+	if password, err := policy.Generate(); err != nil {
+		log.Printf("Error: %v", err)
+	} else {
+		fmt.Printf("策略密码 (14位):     %s\n", password)
+	}
 
-	// 随机组合所有部分
-	parts := []string{upper, lower, numbers, special}
-	shuffled := shuffleStringParts(parts)
-	layeredPassword := strings.Join(shuffled, "")
-	fmt.Printf("分层密码 (14位):     %s\n", layeredPassword)
+	// 易读且不含重复或连续字符的密码
+	readable := rand.PasswordPolicy{
+		Length:       16,
+		MinUpper:     2,
+		MinLower:     2,
+		MinDigits:    2,
+		NoSymbols:    true,
+		NoRepeat:     true,
+		NoSequential: true,
+		VisibleOnly:  true,
+	}
+	if password, err := readable.Generate(); err != nil {
+		log.Printf("Error: %v", err)
+	} else {
+		fmt.Printf("易读密码 (16位):     %s\n", password)
+	}
 
-	fmt.Println()
-}
+	// 可发音密码：辅音元音交替，便于电话中念出
+	fmt.Println("\n--- 可发音密码 ---")
+	spoken := rand.PronounceablePolicy{Length: 10, Digits: 2, Symbols: 1}
+	if password, err := spoken.Generate(); err != nil {
+		log.Printf("Error: %v", err)
+	} else {
+		bits, _ := spoken.Entropy()
+		fmt.Printf("可发音密码 (%.0f 位熵): %s\n", bits, password)
+	}
 
-// shuffleStringParts randomly shuffles string parts (simple demonstration)
-func shuffleStringParts(parts []string) []string {
-	n := len(parts)
-	for i := 0; i < n*2; i++ {
-		a := rand.RangeInt(0, n)
-		b := rand.RangeInt(0, n)
-		parts[a], parts[b] = parts[b], parts[a]
+	// Diceware 口令：取自 EFF 词表，便于记忆
+	fmt.Println("\n--- 口令生成 ---")
+	fmt.Printf("口令 (6词):          %s\n", rand.Passphrase(6))
+	phrase := rand.PassphrasePolicy{
+		Words:          5,
+		Separator:      " ",
+		Capitalization: rand.CapitalizeFirst,
+		Digits:         1,
+		Wordlist:       rand.EFFShortWordlist(),
+	}
+	if passphrase, err := phrase.Generate(); err != nil {
+		log.Printf("Error: %v", err)
+	} else {
+		bits, _ := phrase.Entropy()
+		fmt.Printf("恢复口令 (%.0f 位熵): %s\n", bits, passphrase)
 	}
-	return parts
+
+	fmt.Println()
 }
 
 // demonstrateTokenGeneration shows various token generation patterns
@@ -0,0 +1,199 @@
+package rand
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// Float64 returns a cryptographically secure random float64 in the range [0.0, 1.0).
+// The result is a uniformly chosen multiple of 2^-53, the same grid as math/rand.
+// Use Float64Full to reach every representable float64 in [0, 1).
+//
+// Example:
+//
+//	p := rand.Float64() // Returns a value like 0.6046602879796196
+func Float64() float64 {
+	return defaultGenerator.Float64()
+}
+
+// Float64 is like the package-level Float64 but draws from g.
+func (g *Generator) Float64() float64 {
+	return must(g.Float64E())
+}
+
+// Float64E is like Float64 but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func Float64E() (float64, error) {
+	return defaultGenerator.Float64E()
+}
+
+// Float64E is like the package-level Float64E but draws from g.
+func (g *Generator) Float64E() (float64, error) {
+	// The top 53 bits fill the mantissa exactly, so no rounding can produce 1.0
+	n, err := g.uint64()
+	return float64(n>>11) / (1 << 53), err
+}
+
+// Float32 returns a cryptographically secure random float32 in the range [0.0, 1.0).
+// The result is a uniformly chosen multiple of 2^-24.
+//
+// Example:
+//
+//	p := rand.Float32() // Returns a value like 0.9405091
+func Float32() float32 {
+	return defaultGenerator.Float32()
+}
+
+// Float32 is like the package-level Float32 but draws from g.
+func (g *Generator) Float32() float32 {
+	return must(g.Float32E())
+}
+
+// Float32E is like Float32 but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func Float32E() (float32, error) {
+	return defaultGenerator.Float32E()
+}
+
+// Float32E is like the package-level Float32E but draws from g.
+func (g *Generator) Float32E() (float32, error) {
+	n, err := g.uint64()
+	return float32(n>>40) / (1 << 24), err
+}
+
+// Float64Full returns a cryptographically secure random float64 in the range [0.0, 1.0)
+// that can be any representable float64 in that range, not only a multiple of 2^-53.
+//
+// Each float64 x is returned with probability equal to the distance to its successor,
+// so the result is the exact uniform real in [0, 1) rounded down. Small values keep
+// their full 52-bit precision: Float64 never returns a value in (0, 2^-53), while
+// Float64Full does with the correct probability. It consumes two 64-bit draws in
+// the common case.
+//
+// Example:
+//
+//	x := rand.Float64Full() // Returns a value like 0.0000123456789012345
+func Float64Full() float64 {
+	return defaultGenerator.Float64Full()
+}
+
+// Float64Full is like the package-level Float64Full but draws from g.
+func (g *Generator) Float64Full() float64 {
+	return must(g.Float64FullE())
+}
+
+// Float64FullE is like Float64Full but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func Float64FullE() (float64, error) {
+	return defaultGenerator.Float64FullE()
+}
+
+// Float64FullE is like the package-level Float64FullE but draws from g.
+func (g *Generator) Float64FullE() (float64, error) {
+	// Pick the binade [2^exp, 2^(exp+1)) with probability 2^exp by counting
+	// leading zero bits of a random bit stream
+	exp := -1
+	for {
+		n, err := g.uint64()
+		if err != nil {
+			return 0, err
+		}
+		if n != 0 {
+			exp -= bits.LeadingZeros64(n)
+			break
+		}
+		exp -= 64
+		if exp < -1074 { // Below the smallest subnormal
+			return 0, nil
+		}
+	}
+
+	// Pick the mantissa uniformly within the binade
+	n, err := g.uint64()
+	if err != nil {
+		return 0, err
+	}
+	mantissa := n>>12 | 1<<52
+	return math.Ldexp(float64(mantissa), exp-52), nil
+}
+
+// RangeFloat64Safe returns a cryptographically secure random float64 in the range [min, max).
+// It returns min if min == max.
+//
+// The function draws from the same secure source and fallback policy as Int64.
+// Bounds spanning more than math.MaxFloat64, such as [-math.MaxFloat64, math.MaxFloat64),
+// are supported.
+//
+// Parameters:
+//   - min: the minimum value (inclusive)
+//   - max: the maximum value (exclusive)
+//
+// Returns:
+//   - A random float64 in [min, max)
+//   - An error wrapping ErrInvalidRange if min > max or either bound is NaN or infinite,
+//     or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	x, err := rand.RangeFloat64Safe(-1.5, 1.5)
+//	if err != nil {
+//		// Handle error
+//	}
+func RangeFloat64Safe(min, max float64) (float64, error) {
+	return defaultGenerator.RangeFloat64Safe(min, max)
+}
+
+// RangeFloat64Safe is like the package-level RangeFloat64Safe but draws from g.
+func (g *Generator) RangeFloat64Safe(min, max float64) (float64, error) {
+	if math.IsNaN(min) || math.IsNaN(max) || math.IsInf(min, 0) || math.IsInf(max, 0) {
+		return 0, fmt.Errorf("%w: bounds must be finite", ErrInvalidRange)
+	}
+	if min > max {
+		return 0, ErrInvalidRange
+	}
+	if min == max {
+		return min, nil
+	}
+
+	for {
+		u, err := g.Float64E()
+		if err != nil {
+			return 0, err
+		}
+
+		var x float64
+		if span := max - min; !math.IsInf(span, 0) {
+			x = min + span*u
+		} else {
+			// Halve the bounds so the span stays finite
+			x = 2 * (min/2 + (max/2-min/2)*u)
+		}
+
+		// Rounding can land exactly on max; redraw to keep the interval half-open
+		if x >= min && x < max {
+			return x, nil
+		}
+	}
+}
+
+// RangeFloat64 returns a cryptographically secure random float64 in the range [min, max).
+// It returns 0 if the range is invalid, matching RangeInt. In strict mode
+// (see SetStrict) it panics if the entropy source fails.
+//
+// Example:
+//
+//	temperature := rand.RangeFloat64(-10, 35) // Returns a value like 21.73
+func RangeFloat64(min, max float64) float64 {
+	return defaultGenerator.RangeFloat64(min, max)
+}
+
+// RangeFloat64 is like the package-level RangeFloat64 but draws from g.
+func (g *Generator) RangeFloat64(min, max float64) float64 {
+	result, err := g.RangeFloat64Safe(min, max)
+	if errors.Is(err, ErrInvalidRange) {
+		return 0
+	}
+	return must(result, err)
+}
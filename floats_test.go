@@ -0,0 +1,133 @@
+package rand
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFloat64 validates the Float64 function
+func TestFloat64(t *testing.T) {
+	const iterations = 100000
+
+	sum := 0.0
+	for i := 0; i < iterations; i++ {
+		f := Float64()
+		assert.GreaterOrEqual(t, f, 0.0)
+		assert.Less(t, f, 1.0)
+		sum += f
+	}
+	assert.InDelta(t, 0.5, sum/iterations, 0.01, "Float64 mean should be about 0.5")
+
+	// All-ones input yields the largest value below 1
+	g := NewGenerator(constantSource(math.MaxUint64))
+	assert.Equal(t, math.Nextafter(1, 0), g.Float64())
+	assert.Equal(t, math.Nextafter32(1, 0), g.Float32())
+}
+
+// TestFloat32 validates the Float32 function
+func TestFloat32(t *testing.T) {
+	const iterations = 100000
+
+	var sum float64
+	for i := 0; i < iterations; i++ {
+		f := Float32()
+		assert.GreaterOrEqual(t, f, float32(0))
+		assert.Less(t, f, float32(1))
+		sum += float64(f)
+	}
+	assert.InDelta(t, 0.5, sum/iterations, 0.01, "Float32 mean should be about 0.5")
+}
+
+// TestFloat64Full validates that every representable float in [0, 1) is reachable
+func TestFloat64Full(t *testing.T) {
+	const iterations = 100000
+
+	offGrid, small := 0, 0
+	for i := 0; i < iterations; i++ {
+		f := Float64Full()
+		require.GreaterOrEqual(t, f, 0.0)
+		require.Less(t, f, 1.0)
+
+		// Float64 only returns multiples of 2^-53
+		if f < 0.5 && math.Mod(f*(1<<53), 1) != 0 {
+			offGrid++
+		}
+		if f < 1.0/1024 {
+			small++
+		}
+	}
+	assert.Greater(t, offGrid, iterations/4, "Float64Full should produce values off the 2^-53 grid")
+	assert.InDelta(t, iterations/1024, small, 40, "P(x < 2^-10) should be 2^-10")
+
+	// The largest value is the predecessor of 1
+	g := NewGenerator(constantSource(math.MaxUint64))
+	assert.Equal(t, math.Nextafter(1, 0), g.Float64Full())
+
+	// An all-zero stream yields 0 rather than looping forever
+	g = NewGenerator(constantSource(0))
+	assert.Equal(t, 0.0, g.Float64Full())
+}
+
+// TestRangeFloat64 validates the RangeFloat64 family
+func TestRangeFloat64(t *testing.T) {
+	testCases := [][2]float64{
+		{0, 1},
+		{-10, 35},
+		{-1e-300, 1e-300},
+		{1e15, 1e15 + 1},
+		{-math.MaxFloat64, math.MaxFloat64},
+	}
+
+	for _, tc := range testCases {
+		for i := 0; i < 1000; i++ {
+			x, err := RangeFloat64Safe(tc[0], tc[1])
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, x, tc[0], "RangeFloat64Safe(%g, %g) returned %g", tc[0], tc[1], x)
+			assert.Less(t, x, tc[1], "RangeFloat64Safe(%g, %g) returned %g", tc[0], tc[1], x)
+		}
+	}
+
+	// Rounding up to max is rejected, so the smallest range only yields min
+	g := NewSeeded(1)
+	for i := 0; i < 100; i++ {
+		x, err := g.RangeFloat64Safe(0, math.Nextafter(0, 1))
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, x)
+	}
+
+	// Edge cases
+	assert.Equal(t, 2.5, RangeFloat64(2.5, 2.5))
+	assert.Equal(t, 0.0, RangeFloat64(3, 1))
+
+	invalid := [][2]float64{{3, 1}, {math.NaN(), 1}, {0, math.Inf(1)}, {math.Inf(-1), 0}}
+	for _, tc := range invalid {
+		_, err := RangeFloat64Safe(tc[0], tc[1])
+		assert.ErrorIs(t, err, ErrInvalidRange, "RangeFloat64Safe(%g, %g)", tc[0], tc[1])
+	}
+
+	strict := NewGenerator(failingSource{}, WithStrict())
+	_, err := strict.RangeFloat64Safe(0, 1)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = strict.Float64E()
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	assert.Panics(t, func() { strict.Float32() })
+}
+
+// BenchmarkFloat64 benchmarks the Float64 function
+func BenchmarkFloat64(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Float64()
+	}
+}
+
+// BenchmarkFloat64Full benchmarks the Float64Full function
+func BenchmarkFloat64Full(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Float64Full()
+	}
+}
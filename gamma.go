@@ -0,0 +1,324 @@
+package rand
+
+import (
+	"fmt"
+	"math"
+)
+
+// GammaSafe returns a cryptographically secure gamma-distributed float64 with
+// the given shape and scale, so the mean is shape*scale.
+//
+// It uses the method of Marsaglia and Tsang, which accepts about 95% or more
+// of its candidates for every shape. Shapes below 1 are boosted to shape+1
+// and scaled back by U^(1/shape).
+//
+// Parameters:
+//   - shape: the shape parameter (k or alpha), which must be finite and positive
+//   - scale: the scale parameter (theta), which must be finite and positive
+//
+// Returns:
+//   - A value in [0, +Inf)
+//   - An error wrapping ErrInvalidParameter if a parameter is out of its domain,
+//     or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	wait, err := rand.GammaSafe(3, 2) // Sum of three exponential waits with mean 2
+//	if err != nil {
+//		// Handle error
+//	}
+func GammaSafe(shape, scale float64) (float64, error) {
+	return defaultGenerator.GammaSafe(shape, scale)
+}
+
+// GammaSafe is like the package-level GammaSafe but draws from g.
+func (g *Generator) GammaSafe(shape, scale float64) (float64, error) {
+	if !isFinite(shape) || shape <= 0 {
+		return 0, invalidParameter("shape", shape, "finite and > 0")
+	}
+	if !isFinite(scale) || scale <= 0 {
+		return 0, invalidParameter("scale", scale, "finite and > 0")
+	}
+
+	x, err := g.standardGamma(shape)
+	if err != nil {
+		return 0, err
+	}
+	return x * scale, nil
+}
+
+// Gamma returns a cryptographically secure gamma-distributed float64 with the
+// given shape and scale. It returns 0 if a parameter is invalid, matching
+// RangeInt. In strict mode (see SetStrict) it panics if the entropy source fails.
+//
+// Example:
+//
+//	wait := rand.Gamma(3, 2)
+func Gamma(shape, scale float64) float64 {
+	return defaultGenerator.Gamma(shape, scale)
+}
+
+// Gamma is like the package-level Gamma but draws from g.
+func (g *Generator) Gamma(shape, scale float64) float64 {
+	return orZero(g.GammaSafe(shape, scale))
+}
+
+// BetaSafe returns a cryptographically secure beta-distributed float64 in
+// [0, 1] with shape parameters alpha and beta, so the mean is
+// alpha/(alpha+beta). Beta(successes+1, failures+1) is the posterior of a
+// success rate under a uniform prior, as used by Thompson sampling.
+//
+// It is computed as X/(X+Y) for gamma variates X and Y, in logarithms so
+// that very small shapes do not underflow to 0/0.
+//
+// Parameters:
+//   - alpha: the first shape parameter, which must be finite and positive
+//   - beta: the second shape parameter, which must be finite and positive
+//
+// Returns:
+//   - A value in [0, 1]
+//   - An error wrapping ErrInvalidParameter if a parameter is out of its domain,
+//     or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	// Thompson sampling: play the arm with the highest draw
+//	score, err := rand.BetaSafe(float64(wins+1), float64(losses+1))
+//	if err != nil {
+//		// Handle error
+//	}
+func BetaSafe(alpha, beta float64) (float64, error) {
+	return defaultGenerator.BetaSafe(alpha, beta)
+}
+
+// BetaSafe is like the package-level BetaSafe but draws from g.
+func (g *Generator) BetaSafe(alpha, beta float64) (float64, error) {
+	if !isFinite(alpha) || alpha <= 0 {
+		return 0, invalidParameter("alpha", alpha, "finite and > 0")
+	}
+	if !isFinite(beta) || beta <= 0 {
+		return 0, invalidParameter("beta", beta, "finite and > 0")
+	}
+
+	logX, err := g.logStandardGamma(alpha)
+	if err != nil {
+		return 0, err
+	}
+	logY, err := g.logStandardGamma(beta)
+	if err != nil {
+		return 0, err
+	}
+
+	// X/(X+Y) = 1/(1 + e^(logY-logX))
+	return 1 / (1 + math.Exp(logY-logX)), nil
+}
+
+// Beta returns a cryptographically secure beta-distributed float64 in [0, 1]
+// with shape parameters alpha and beta. It returns 0 if a parameter is
+// invalid, matching RangeInt. In strict mode (see SetStrict) it panics if the
+// entropy source fails.
+//
+// Example:
+//
+//	score := rand.Beta(float64(wins+1), float64(losses+1))
+func Beta(alpha, beta float64) float64 {
+	return defaultGenerator.Beta(alpha, beta)
+}
+
+// Beta is like the package-level Beta but draws from g.
+func (g *Generator) Beta(alpha, beta float64) float64 {
+	return orZero(g.BetaSafe(alpha, beta))
+}
+
+// DirichletSafe returns a cryptographically secure Dirichlet-distributed
+// probability vector: len(alpha) non-negative values that sum to 1, where
+// component i has mean alpha[i]/sum(alpha). It generalizes Beta to more than
+// two outcomes.
+//
+// Parameters:
+//   - alpha: the concentration parameters; there must be at least one, and
+//     each must be finite and positive
+//
+// Returns:
+//   - A new slice of len(alpha) probabilities summing to 1
+//   - An error wrapping ErrInvalidParameter if alpha is empty or an element is
+//     out of its domain, or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	// Sample a plausible click-through distribution over three variants
+//	p, err := rand.DirichletSafe([]float64{12, 3, 7})
+//	if err != nil {
+//		// Handle error
+//	}
+func DirichletSafe(alpha []float64) ([]float64, error) {
+	return defaultGenerator.DirichletSafe(alpha)
+}
+
+// DirichletSafe is like the package-level DirichletSafe but draws from g.
+func (g *Generator) DirichletSafe(alpha []float64) ([]float64, error) {
+	if len(alpha) == 0 {
+		return nil, fmt.Errorf("%w: alpha is empty", ErrInvalidParameter)
+	}
+	for i, a := range alpha {
+		if !isFinite(a) || a <= 0 {
+			return nil, invalidParameter(fmt.Sprintf("alpha[%d]", i), a, "finite and > 0")
+		}
+	}
+
+	// Normalize gamma variates in logarithms, relative to the largest, so
+	// that small concentrations do not underflow
+	p := make([]float64, len(alpha))
+	maxLog := math.Inf(-1)
+	for i, a := range alpha {
+		logX, err := g.logStandardGamma(a)
+		if err != nil {
+			return nil, err
+		}
+		p[i] = logX
+		maxLog = math.Max(maxLog, logX)
+	}
+
+	var sum float64
+	for i := range p {
+		p[i] = math.Exp(p[i] - maxLog)
+		sum += p[i]
+	}
+	for i := range p {
+		p[i] /= sum
+	}
+	return p, nil
+}
+
+// Dirichlet returns a cryptographically secure Dirichlet-distributed
+// probability vector with concentration parameters alpha. It returns nil if
+// alpha is invalid, matching RangeInt. In strict mode (see SetStrict) it
+// panics if the entropy source fails.
+//
+// Example:
+//
+//	p := rand.Dirichlet([]float64{1, 1, 1}) // Uniform over the 2-simplex
+func Dirichlet(alpha []float64) []float64 {
+	return defaultGenerator.Dirichlet(alpha)
+}
+
+// Dirichlet is like the package-level Dirichlet but draws from g.
+func (g *Generator) Dirichlet(alpha []float64) []float64 {
+	return orZero(g.DirichletSafe(alpha))
+}
+
+// ChiSquaredSafe returns a cryptographically secure chi-squared-distributed
+// float64 with k degrees of freedom: the distribution of the sum of k squared
+// standard normal variates, computed as Gamma(k/2, 2).
+//
+// Parameters:
+//   - k: the degrees of freedom, which must be finite and positive; it need
+//     not be an integer
+//
+// Returns:
+//   - A value in [0, +Inf)
+//   - An error wrapping ErrInvalidParameter if k is out of its domain,
+//     or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	x, err := rand.ChiSquaredSafe(4)
+//	if err != nil {
+//		// Handle error
+//	}
+func ChiSquaredSafe(k float64) (float64, error) {
+	return defaultGenerator.ChiSquaredSafe(k)
+}
+
+// ChiSquaredSafe is like the package-level ChiSquaredSafe but draws from g.
+func (g *Generator) ChiSquaredSafe(k float64) (float64, error) {
+	if !isFinite(k) || k <= 0 {
+		return 0, invalidParameter("k", k, "finite and > 0")
+	}
+
+	x, err := g.standardGamma(k / 2)
+	if err != nil {
+		return 0, err
+	}
+	return 2 * x, nil
+}
+
+// ChiSquared returns a cryptographically secure chi-squared-distributed
+// float64 with k degrees of freedom. It returns 0 if k is invalid, matching
+// RangeInt. In strict mode (see SetStrict) it panics if the entropy source fails.
+//
+// Example:
+//
+//	x := rand.ChiSquared(4)
+func ChiSquared(k float64) float64 {
+	return defaultGenerator.ChiSquared(k)
+}
+
+// ChiSquared is like the package-level ChiSquared but draws from g.
+func (g *Generator) ChiSquared(k float64) float64 {
+	return orZero(g.ChiSquaredSafe(k))
+}
+
+// standardGamma returns a gamma variate with the given positive shape and scale 1
+func (g *Generator) standardGamma(shape float64) (float64, error) {
+	if shape >= 1 {
+		return g.marsagliaTsang(shape)
+	}
+	logX, err := g.logStandardGamma(shape)
+	if err != nil {
+		return 0, err
+	}
+	return math.Exp(logX), nil
+}
+
+// logStandardGamma returns the logarithm of a gamma variate with the given
+// positive shape and scale 1. For shapes below 1 it uses
+// Gamma(shape) = Gamma(shape+1) * U^(1/shape), whose logarithm stays
+// representable even when the variate itself underflows.
+func (g *Generator) logStandardGamma(shape float64) (float64, error) {
+	if shape >= 1 {
+		x, err := g.marsagliaTsang(shape)
+		if err != nil {
+			return 0, err
+		}
+		return math.Log(x), nil
+	}
+
+	x, err := g.marsagliaTsang(shape + 1)
+	if err != nil {
+		return 0, err
+	}
+	u, err := g.openFloat64()
+	if err != nil {
+		return 0, err
+	}
+	return math.Log(x) + math.Log(u)/shape, nil
+}
+
+// marsagliaTsang returns a gamma variate with shape >= 1 and scale 1: d*v for
+// v = (1 + c*z)^3 with a normal z, accepted with a squeeze before the exact test
+func (g *Generator) marsagliaTsang(shape float64) (float64, error) {
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+
+	for {
+		z, err := g.normFloat64()
+		if err != nil {
+			return 0, err
+		}
+		v := 1 + c*z
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+
+		u, err := g.openFloat64()
+		if err != nil {
+			return 0, err
+		}
+		z2 := z * z
+		if u < 1-0.0331*z2*z2 || math.Log(u) < 0.5*z2+d*(1-v+math.Log(v)) {
+			return d * v, nil
+		}
+	}
+}
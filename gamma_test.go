@@ -0,0 +1,257 @@
+package rand
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lentzFloor keeps the denominators of Lentz's continued fraction method away from zero
+const lentzFloor = 1e-300
+
+// lentzClamp replaces values too close to zero by lentzFloor
+func lentzClamp(x float64) float64 {
+	if math.Abs(x) < lentzFloor {
+		return lentzFloor
+	}
+	return x
+}
+
+// regIncGamma returns the regularized lower incomplete gamma function P(a, x),
+// the CDF of Gamma(a, 1) at x, by its series or continued fraction
+func regIncGamma(a, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	lg, _ := math.Lgamma(a)
+	prefix := math.Exp(-x + a*math.Log(x) - lg)
+
+	if x < a+1 {
+		sum, del := 1/a, 1/a
+		for n := 1.0; math.Abs(del) > math.Abs(sum)*1e-15; n++ {
+			del *= x / (a + n)
+			sum += del
+		}
+		return sum * prefix
+	}
+
+	b := x + 1 - a
+	c, d := 1/lentzFloor, 1/b
+	h := d
+	for i := 1.0; i < 1000; i++ {
+		an := -i * (i - a)
+		b += 2
+		d = 1 / lentzClamp(an*d+b)
+		c = lentzClamp(b + an/c)
+		h *= d * c
+		if math.Abs(d*c-1) < 1e-15 {
+			break
+		}
+	}
+	return 1 - prefix*h
+}
+
+// regIncBeta returns the regularized incomplete beta function I_x(a, b),
+// the CDF of Beta(a, b) at x, by its continued fraction
+func regIncBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	lab, _ := math.Lgamma(a + b)
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	prefix := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log1p(-x))
+
+	if x < (a+1)/(a+b+2) {
+		return prefix * betaFraction(a, b, x) / a
+	}
+	return 1 - prefix*betaFraction(b, a, 1-x)/b
+}
+
+// betaFraction evaluates the continued fraction of the incomplete beta function
+func betaFraction(a, b, x float64) float64 {
+	c, d := 1.0, 1/lentzClamp(1-(a+b)*x/(a+1))
+	h := d
+	for m := 1.0; m < 1000; m++ {
+		aa := m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m))
+		d = 1 / lentzClamp(1+aa*d)
+		c = lentzClamp(1 + aa/c)
+		h *= d * c
+
+		aa = -(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1))
+		d = 1 / lentzClamp(1+aa*d)
+		c = lentzClamp(1 + aa/c)
+		h *= d * c
+		if math.Abs(d*c-1) < 1e-15 {
+			break
+		}
+	}
+	return h
+}
+
+// TestIncompleteFunctions checks the reference CDFs used by the tests below
+func TestIncompleteFunctions(t *testing.T) {
+	// Gamma(1) is exponential and Beta(1, 1) is uniform
+	assert.InDelta(t, -math.Expm1(-2.5), regIncGamma(1, 2.5), 1e-12)
+	assert.InDelta(t, -math.Expm1(-0.3), regIncGamma(1, 0.3), 1e-12)
+	assert.InDelta(t, 0.37, regIncBeta(1, 1, 0.37), 1e-12)
+	// Beta(2, 1) has CDF x^2; chi-squared with 2 degrees of freedom has CDF 1-e^(-x/2)
+	assert.InDelta(t, 0.64, regIncBeta(2, 1, 0.8), 1e-12)
+	assert.InDelta(t, 0.5, regIncBeta(7.5, 7.5, 0.5), 1e-12)
+	assert.InDelta(t, -math.Expm1(-3.0/2), regIncGamma(2.0/2, 3.0/2), 1e-12)
+}
+
+// TestGamma validates the gamma distribution with KS tests on both branches
+func TestGamma(t *testing.T) {
+	const n = 100000
+
+	for _, shape := range []float64{0.1, 0.5, 1, 2.5, 30} {
+		g := NewSeeded(uint64(shape * 10))
+		samples := drawN(n, func() float64 { return g.Gamma(shape, 3) })
+
+		d := ksStatistic(samples, func(x float64) float64 { return regIncGamma(shape, x/3) })
+		assert.Less(t, d, ksCritical(n), "Gamma(%g, 3) failed the KS test", shape)
+	}
+
+	// Tiny shapes underflow to 0 but never produce NaN
+	for i := 0; i < 1000; i++ {
+		x := Gamma(1e-3, 1)
+		require.False(t, math.IsNaN(x) || x < 0, "Gamma(1e-3, 1) returned %g", x)
+	}
+
+	assert.Equal(t, 0.0, Gamma(0, 1))
+	for _, p := range [][2]float64{{0, 1}, {-1, 1}, {1, 0}, {1, -1}, {math.NaN(), 1}, {1, math.Inf(1)}} {
+		_, err := GammaSafe(p[0], p[1])
+		assert.ErrorIs(t, err, ErrInvalidParameter, "GammaSafe(%g, %g)", p[0], p[1])
+	}
+}
+
+// TestBeta validates the beta distribution with KS tests
+func TestBeta(t *testing.T) {
+	const n = 100000
+
+	for _, p := range [][2]float64{{0.5, 0.5}, {1, 1}, {2, 5}, {0.2, 3}, {40, 60}} {
+		g := NewSeeded(uint64(p[0]*100 + p[1]))
+		samples := drawN(n, func() float64 { return g.Beta(p[0], p[1]) })
+		for _, x := range samples {
+			require.True(t, x >= 0 && x <= 1, "Beta(%g, %g) returned %g", p[0], p[1], x)
+		}
+
+		d := ksStatistic(samples, func(x float64) float64 { return regIncBeta(p[0], p[1], x) })
+		assert.Less(t, d, ksCritical(n), "Beta(%g, %g) failed the KS test", p[0], p[1])
+	}
+
+	// Shapes so small that both gamma variates underflow still give a valid
+	// result, split between near 0 and near 1 by the ratio of the shapes
+	var high int
+	for i := 0; i < 10000; i++ {
+		x := Beta(1e-4, 3e-4)
+		require.True(t, x >= 0 && x <= 1, "Beta(1e-4, 3e-4) returned %g", x)
+		if x > 0.5 {
+			high++
+		}
+	}
+	assert.InDelta(t, 2500, high, 250)
+
+	assert.Equal(t, 0.0, Beta(1, 0))
+	for _, p := range [][2]float64{{0, 1}, {1, -1}, {math.Inf(1), 1}, {1, math.NaN()}} {
+		_, err := BetaSafe(p[0], p[1])
+		assert.ErrorIs(t, err, ErrInvalidParameter, "BetaSafe(%g, %g)", p[0], p[1])
+	}
+}
+
+// TestDirichlet checks that Dirichlet returns probability vectors with
+// beta-distributed marginals
+func TestDirichlet(t *testing.T) {
+	const n = 50000
+
+	alpha := []float64{0.3, 2, 5}
+	marginals := make([][]float64, len(alpha))
+	for i := 0; i < n; i++ {
+		p := Dirichlet(alpha)
+		require.Len(t, p, len(alpha))
+
+		var sum float64
+		for j, x := range p {
+			require.True(t, x >= 0 && x <= 1, "component %d is %g", j, x)
+			sum += x
+			marginals[j] = append(marginals[j], x)
+		}
+		require.InDelta(t, 1, sum, 1e-12)
+	}
+
+	// Component j has a Beta(alpha[j], sum(alpha)-alpha[j]) marginal
+	total := 7.3
+	for j, a := range alpha {
+		d := ksStatistic(marginals[j], func(x float64) float64 { return regIncBeta(a, total-a, x) })
+		assert.Less(t, d, ksCritical(n), "Dirichlet component %d failed the KS test", j)
+	}
+
+	// Tiny concentrations still normalize
+	p := Dirichlet([]float64{1e-4, 1e-4, 1e-4})
+	var sum float64
+	for _, x := range p {
+		sum += x
+	}
+	assert.InDelta(t, 1, sum, 1e-12)
+
+	assert.Equal(t, []float64{1}, Dirichlet([]float64{2.5}))
+	assert.Nil(t, Dirichlet(nil))
+	for _, alpha := range [][]float64{nil, {}, {1, 0}, {-1}, {1, math.NaN()}, {math.Inf(1)}} {
+		_, err := DirichletSafe(alpha)
+		assert.ErrorIs(t, err, ErrInvalidParameter, "DirichletSafe(%v)", alpha)
+	}
+}
+
+// TestChiSquared validates the chi-squared distribution with KS tests
+func TestChiSquared(t *testing.T) {
+	const n = 100000
+
+	for _, k := range []float64{1, 2, 3.5, 10} {
+		g := NewSeeded(uint64(k * 10))
+		samples := drawN(n, func() float64 { return g.ChiSquared(k) })
+
+		d := ksStatistic(samples, func(x float64) float64 { return regIncGamma(k/2, x/2) })
+		assert.Less(t, d, ksCritical(n), "ChiSquared(%g) failed the KS test", k)
+	}
+
+	assert.Equal(t, 0.0, ChiSquared(-2))
+	for _, k := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		_, err := ChiSquaredSafe(k)
+		assert.ErrorIs(t, err, ErrInvalidParameter, "ChiSquaredSafe(%g)", k)
+	}
+}
+
+// TestGammaGenerator verifies seeded reproducibility and strict-mode errors
+func TestGammaGenerator(t *testing.T) {
+	a, b := NewSeeded(10), NewSeeded(10)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, a.Gamma(0.7, 1), b.Gamma(0.7, 1))
+		assert.Equal(t, a.Beta(2, 3), b.Beta(2, 3))
+		assert.Equal(t, a.Dirichlet([]float64{1, 2}), b.Dirichlet([]float64{1, 2}))
+		assert.Equal(t, a.ChiSquared(3), b.ChiSquared(3))
+	}
+
+	strict := NewGenerator(failingSource{}, WithStrict())
+	_, err := strict.GammaSafe(2, 1)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = strict.BetaSafe(0.5, 2)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = strict.DirichletSafe([]float64{1, 1})
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = strict.ChiSquaredSafe(3)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	assert.Panics(t, func() { strict.Beta(1, 1) })
+	assert.Nil(t, strict.Dirichlet(nil))
+}
+
+func BenchmarkGamma(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Gamma(2.5, 1)
+	}
+}
@@ -0,0 +1,108 @@
+package rand
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// ErrEntropyUnavailable is returned by strict generators when their Source fails
+var ErrEntropyUnavailable = errors.New("entropy source unavailable")
+
+// Source is the entropy source a Generator draws its random bits from.
+//
+// Read must fill p with random bytes and may only return fewer than len(p)
+// bytes together with a non-nil error. Any io.Reader with these semantics,
+// such as crypto/rand.Reader, can be used as a Source.
+type Source interface {
+	Read(p []byte) (n int, err error)
+}
+
+// Generator produces random values from a pluggable Source.
+//
+// Every package-level function (Int64, RangeInt, String, UUID, ...) has a
+// matching Generator method; the package-level functions are thin wrappers
+// over a default Generator backed by a buffered ChaCha20 entropy pool that is
+// seeded from crypto/rand. Separate Generators allow different parts of a
+// program to use differently configured sources.
+//
+// By default a Generator falls back to math/rand when its Source fails.
+// A strict Generator (see WithStrict) never does: its error-returning
+// variants (Int64E, StringE, UUIDE, ...) report the failure and the other
+// methods panic.
+//
+// A Generator is safe for concurrent use if its Source is.
+type Generator struct {
+	src    Source
+	strict int32 // accessed atomically; non-zero disables the math/rand fallback
+}
+
+// Option configures a Generator created by NewGenerator.
+type Option func(*Generator)
+
+// WithStrict makes the Generator report Source failures instead of
+// falling back to math/rand.
+//
+// Example:
+//
+//	g := rand.NewGenerator(nil, rand.WithStrict())
+//	token, err := g.StringE(32)
+func WithStrict() Option {
+	return func(g *Generator) {
+		g.setStrict(true)
+	}
+}
+
+// defaultGenerator backs all package-level functions.
+var defaultGenerator = NewGenerator(nil)
+
+// NewGenerator returns a Generator that draws its entropy from src.
+//
+// If src is nil, the package's buffered entropy pool is used: a sharded
+// fast-key-erasure ChaCha20 keystream seeded from crypto/rand, which avoids a
+// crypto/rand read per call under high throughput. Pass crypto/rand.Reader
+// explicitly to read from the operating system on every call instead.
+//
+// Example:
+//
+//	g := rand.NewGenerator(cRand.Reader)
+//	token := g.String(32)
+func NewGenerator(src Source, opts ...Option) *Generator {
+	if src == nil {
+		src = defaultEntropyPool
+	}
+	g := &Generator{src: src}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// SetStrict enables or disables strict mode for the package-level functions.
+//
+// In strict mode a crypto/rand failure is returned as an error wrapping
+// ErrEntropyUnavailable by the error-returning variants (Int64E, StringE,
+// UUIDE, ...), and the remaining functions panic instead of silently
+// falling back to math/rand. Strict mode is disabled by default.
+func SetStrict(strict bool) {
+	defaultGenerator.setStrict(strict)
+}
+
+// setStrict toggles strict mode on g
+func (g *Generator) setStrict(strict bool) {
+	var v int32
+	if strict {
+		v = 1
+	}
+	atomic.StoreInt32(&g.strict, v)
+}
+
+// isStrict reports whether g must not fall back to math/rand
+func (g *Generator) isStrict() bool {
+	return atomic.LoadInt32(&g.strict) != 0
+}
+
+// entropyError wraps a Source failure so it matches ErrEntropyUnavailable
+func entropyError(err error) error {
+	return fmt.Errorf("%w: %v", ErrEntropyUnavailable, err)
+}
@@ -0,0 +1,145 @@
+package rand
+
+import (
+	cRand "crypto/rand"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingSource wraps crypto/rand and records how many bytes were read
+type countingSource struct {
+	n int64
+}
+
+func (s *countingSource) Read(p []byte) (int, error) {
+	atomic.AddInt64(&s.n, int64(len(p)))
+	return cRand.Read(p)
+}
+
+// failingSource always fails, forcing the fallback paths
+type failingSource struct{}
+
+func (failingSource) Read(p []byte) (int, error) {
+	return 0, errors.New("entropy unavailable")
+}
+
+// TestNewGenerator validates Generator construction
+func TestNewGenerator(t *testing.T) {
+	g := NewGenerator(nil)
+	assert.Equal(t, defaultEntropyPool, g.src, "NewGenerator(nil) should default to the entropy pool")
+
+	n := g.RangeInt(10, 20)
+	assert.GreaterOrEqual(t, n, 10)
+	assert.Less(t, n, 20)
+}
+
+// TestGeneratorUsesSource validates that every method draws from the configured Source
+func TestGeneratorUsesSource(t *testing.T) {
+	src := &countingSource{}
+	g := NewGenerator(src)
+
+	calls := map[string]func(){
+		"Int32":           func() { g.Int32() },
+		"Int64":           func() { g.Int64() },
+		"Uint32":          func() { g.Uint32() },
+		"Uint64":          func() { g.Uint64() },
+		"Int":             func() { g.Int() },
+		"Uint":            func() { g.Uint() },
+		"RangeInt":        func() { g.RangeInt(0, 100) },
+		"RangeInt64":      func() { g.RangeInt64(0, 100) },
+		"RangeUint32":     func() { g.RangeUint32(0, 100) },
+		"RangeUint64":     func() { g.RangeUint64(0, 100) },
+		"String":          func() { g.String(8) },
+		"VisibleString":   func() { g.VisibleString(8) },
+		"CustomString":    func() { g.CustomString("abc", 8) },
+		"AlphaString":     func() { g.AlphaString(8) },
+		"NumericString":   func() { g.NumericString(8) },
+		"LowercaseString": func() { g.LowercaseString(8) },
+		"UppercaseString": func() { g.UppercaseString(8) },
+		"UUID":            func() { g.UUID() },
+		"Float64":         func() { g.Float64() },
+		"Bool":            func() { g.Bool() },
+		"Chance":          func() { g.Chance(0.5) },
+		"Bytes":           func() { g.Bytes(8) },
+	}
+
+	for name, call := range calls {
+		before := atomic.LoadInt64(&src.n)
+		call()
+		assert.Greater(t, atomic.LoadInt64(&src.n), before, "%s should read from the Source", name)
+	}
+}
+
+// TestGeneratorFallback validates that a failing Source falls back to math/rand
+func TestGeneratorFallback(t *testing.T) {
+	g := NewGenerator(failingSource{})
+
+	n := g.RangeInt(10, 20)
+	assert.GreaterOrEqual(t, n, 10)
+	assert.Less(t, n, 20)
+
+	s := g.String(16)
+	assert.Equal(t, 16, len(s))
+	for _, char := range s {
+		assert.True(t, strings.ContainsRune(NormalLetters, char))
+	}
+
+	assert.Equal(t, 36, len(g.UUID()), "UUID should still be well-formed")
+}
+
+// TestStrictMode validates that strict generators never fall back to math/rand
+func TestStrictMode(t *testing.T) {
+	g := NewGenerator(failingSource{}, WithStrict())
+
+	_, err := g.Int64E()
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+
+	_, err = g.Uint64E()
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+
+	s, err := g.StringE(16)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	assert.Empty(t, s)
+
+	id, err := g.UUIDE()
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	assert.Empty(t, id)
+
+	_, err = g.RangeIntSafe(0, 100)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+
+	// Invalid ranges are still reported as such
+	_, err = g.RangeUint64Safe(10, 5)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+	assert.Equal(t, 0, g.RangeInt(10, 5))
+
+	// Infallible variants panic instead of returning predictable values
+	assert.Panics(t, func() { g.Int64() })
+	assert.Panics(t, func() { g.String(16) })
+	assert.Panics(t, func() { g.RangeInt(0, 100) })
+	assert.Panics(t, func() { g.UUID() })
+}
+
+// TestSetStrict validates the package-level strict mode switch
+func TestSetStrict(t *testing.T) {
+	SetStrict(true)
+	defer SetStrict(false)
+
+	assert.True(t, defaultGenerator.isStrict())
+
+	n, err := Int64E()
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, n, int64(0))
+
+	s, err := StringE(16)
+	assert.NoError(t, err)
+	assert.Equal(t, 16, len(s))
+
+	id, err := UUIDE()
+	assert.NoError(t, err)
+	assert.Equal(t, 36, len(id))
+}
@@ -0,0 +1,226 @@
+package rand
+
+import (
+	"fmt"
+	"math"
+)
+
+// EarthRadius is the mean radius of the Earth in meters, as used by GeoPointNear
+const EarthRadius = 6371008.8
+
+// LatLng is a geographic coordinate in degrees: Lat in [-90, 90] is north of
+// the equator and Lng in [-180, 180] is east of the prime meridian.
+type LatLng struct {
+	Lat, Lng float64
+}
+
+// checkLatLng reports whether c is a valid coordinate
+func checkLatLng(name string, c LatLng) error {
+	if !isFinite(c.Lat) || c.Lat < -90 || c.Lat > 90 {
+		return invalidParameter(name+".Lat", c.Lat, "in [-90, 90]")
+	}
+	if !isFinite(c.Lng) || c.Lng < -180 || c.Lng > 180 {
+		return invalidParameter(name+".Lng", c.Lng, "in [-180, 180]")
+	}
+	return nil
+}
+
+// normalizeLng wraps a longitude in degrees into [-180, 180)
+func normalizeLng(lng float64) float64 {
+	lng = math.Mod(lng+180, 360)
+	if lng < 0 {
+		lng += 360
+	}
+	return lng - 180
+}
+
+// GeoPoint returns a cryptographically secure random coordinate uniformly
+// distributed over the surface of the Earth, treated as a sphere.
+//
+// Drawing latitude uniformly would crowd points near the poles, where a degree
+// of latitude spans less area; instead the sine of the latitude is uniform,
+// as Archimedes' hat-box theorem requires.
+//
+// Example:
+//
+//	c := rand.GeoPoint() // Returns a coordinate like {Lat: -23.6, Lng: 141.2}
+func GeoPoint() LatLng {
+	return defaultGenerator.GeoPoint()
+}
+
+// GeoPoint is like the package-level GeoPoint but draws from g.
+func (g *Generator) GeoPoint() LatLng {
+	return must(g.GeoPointE())
+}
+
+// GeoPointE is like GeoPoint but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func GeoPointE() (LatLng, error) {
+	return defaultGenerator.GeoPointE()
+}
+
+// GeoPointE is like the package-level GeoPointE but draws from g.
+func (g *Generator) GeoPointE() (LatLng, error) {
+	return g.geoPointInBand(-90, 90, -180, 360)
+}
+
+// geoPointInBand returns a uniform coordinate with latitude in [south, north]
+// and longitude in [west, west+span], wrapped into [-180, 180)
+func (g *Generator) geoPointInBand(south, north, west, span float64) (LatLng, error) {
+	u, err := g.Float64E()
+	if err != nil {
+		return LatLng{}, err
+	}
+	v, err := g.Float64E()
+	if err != nil {
+		return LatLng{}, err
+	}
+
+	lo, hi := math.Sin(south*math.Pi/180), math.Sin(north*math.Pi/180)
+	lat := math.Asin(math.Max(-1, math.Min(1, lerp(lo, hi, u)))) * 180 / math.Pi
+	// Rounding in the round trip through the sine must not leave the band
+	lat = math.Max(south, math.Min(north, lat))
+	return LatLng{Lat: lat, Lng: normalizeLng(west + v*span)}, nil
+}
+
+// GeoPointInBoxSafe returns a cryptographically secure random coordinate
+// uniformly distributed by area within the bounding box with the given
+// south-west and north-east corners. A box whose west edge is east of its
+// east edge crosses the antimeridian, so
+// GeoPointInBoxSafe(LatLng{-20, 170}, LatLng{-10, -170}) draws from a
+// 20-degree wide box around Fiji.
+//
+// Parameters:
+//   - southWest: the corner with the smallest latitude and the western longitude
+//   - northEast: the corner with the largest latitude and the eastern longitude;
+//     its latitude must be at least southWest.Lat
+//
+// Returns:
+//   - A coordinate in the box, with Lng wrapped into [-180, 180)
+//   - An error wrapping ErrInvalidParameter if a corner is invalid,
+//     or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	// Somewhere in the contiguous United States' bounding box
+//	c, err := rand.GeoPointInBoxSafe(rand.LatLng{Lat: 24.5, Lng: -125}, rand.LatLng{Lat: 49.4, Lng: -66.9})
+//	if err != nil {
+//		// Handle error
+//	}
+func GeoPointInBoxSafe(southWest, northEast LatLng) (LatLng, error) {
+	return defaultGenerator.GeoPointInBoxSafe(southWest, northEast)
+}
+
+// GeoPointInBoxSafe is like the package-level GeoPointInBoxSafe but draws from g.
+func (g *Generator) GeoPointInBoxSafe(southWest, northEast LatLng) (LatLng, error) {
+	if err := checkLatLng("southWest", southWest); err != nil {
+		return LatLng{}, err
+	}
+	if err := checkLatLng("northEast", northEast); err != nil {
+		return LatLng{}, err
+	}
+	if northEast.Lat < southWest.Lat {
+		return LatLng{}, fmt.Errorf("%w: north latitude %v is below south latitude %v",
+			ErrInvalidParameter, northEast.Lat, southWest.Lat)
+	}
+
+	span := northEast.Lng - southWest.Lng
+	if span < 0 {
+		span += 360 // Crosses the antimeridian
+	}
+	return g.geoPointInBand(southWest.Lat, northEast.Lat, southWest.Lng, span)
+}
+
+// GeoPointInBox returns a cryptographically secure random coordinate uniformly
+// distributed within the bounding box with the given corners. It returns the
+// zero LatLng if a corner is invalid, matching RangeInt. In strict mode (see
+// SetStrict) it panics if the entropy source fails.
+//
+// Example:
+//
+//	c := rand.GeoPointInBox(rand.LatLng{Lat: 24.5, Lng: -125}, rand.LatLng{Lat: 49.4, Lng: -66.9})
+func GeoPointInBox(southWest, northEast LatLng) LatLng {
+	return defaultGenerator.GeoPointInBox(southWest, northEast)
+}
+
+// GeoPointInBox is like the package-level GeoPointInBox but draws from g.
+func (g *Generator) GeoPointInBox(southWest, northEast LatLng) LatLng {
+	return orZero(g.GeoPointInBoxSafe(southWest, northEast))
+}
+
+// GeoPointNearSafe returns a cryptographically secure random coordinate
+// uniformly distributed by area within radius meters of center, measured
+// along the surface of a sphere of radius EarthRadius. It works across the
+// poles and the antimeridian; a radius of half the Earth's circumference or
+// more covers the whole sphere.
+//
+// Parameters:
+//   - center: the center of the circle
+//   - radius: the great-circle radius in meters, which must be finite and non-negative
+//
+// Returns:
+//   - A coordinate within the circle, with Lng wrapped into [-180, 180)
+//   - An error wrapping ErrInvalidParameter if a parameter is invalid,
+//     or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	// A delivery address within 5 km of Berlin's city center
+//	c, err := rand.GeoPointNearSafe(rand.LatLng{Lat: 52.52, Lng: 13.405}, 5000)
+//	if err != nil {
+//		// Handle error
+//	}
+func GeoPointNearSafe(center LatLng, radius float64) (LatLng, error) {
+	return defaultGenerator.GeoPointNearSafe(center, radius)
+}
+
+// GeoPointNearSafe is like the package-level GeoPointNearSafe but draws from g.
+func (g *Generator) GeoPointNearSafe(center LatLng, radius float64) (LatLng, error) {
+	if err := checkLatLng("center", center); err != nil {
+		return LatLng{}, err
+	}
+	if !isFinite(radius) || radius < 0 {
+		return LatLng{}, invalidParameter("radius", radius, "finite and >= 0")
+	}
+
+	u, err := g.Float64E()
+	if err != nil {
+		return LatLng{}, err
+	}
+	v, err := g.Float64E()
+	if err != nil {
+		return LatLng{}, err
+	}
+
+	// The area of a spherical cap grows as 1-cos(d) = 2sin²(d/2), so a
+	// uniform point has sin(d/2) = sqrt(u)*sin(maxAngle/2); this form stays
+	// accurate for distances of a few meters
+	maxAngle := math.Min(radius/EarthRadius, math.Pi)
+	d := 2 * math.Asin(math.Sqrt(u)*math.Sin(maxAngle/2))
+	bearing := 2 * math.Pi * v
+
+	// Destination point given distance and bearing from the center
+	lat1, lng1 := center.Lat*math.Pi/180, center.Lng*math.Pi/180
+	sinLat := math.Sin(lat1)*math.Cos(d) + math.Cos(lat1)*math.Sin(d)*math.Cos(bearing)
+	lat2 := math.Asin(math.Max(-1, math.Min(1, sinLat)))
+	lng2 := lng1 + math.Atan2(math.Sin(bearing)*math.Sin(d)*math.Cos(lat1), math.Cos(d)-math.Sin(lat1)*sinLat)
+
+	return LatLng{Lat: lat2 * 180 / math.Pi, Lng: normalizeLng(lng2 * 180 / math.Pi)}, nil
+}
+
+// GeoPointNear returns a cryptographically secure random coordinate uniformly
+// distributed within radius meters of center. It returns the zero LatLng if
+// a parameter is invalid, matching RangeInt. In strict mode (see SetStrict)
+// it panics if the entropy source fails.
+//
+// Example:
+//
+//	c := rand.GeoPointNear(rand.LatLng{Lat: 52.52, Lng: 13.405}, 5000)
+func GeoPointNear(center LatLng, radius float64) LatLng {
+	return defaultGenerator.GeoPointNear(center, radius)
+}
+
+// GeoPointNear is like the package-level GeoPointNear but draws from g.
+func (g *Generator) GeoPointNear(center LatLng, radius float64) LatLng {
+	return orZero(g.GeoPointNearSafe(center, radius))
+}
@@ -0,0 +1,144 @@
+package rand
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// haversine returns the great-circle distance in meters between a and b
+func haversine(a, b LatLng) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat, dLng := lat2-lat1, (b.Lng-a.Lng)*math.Pi/180
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// TestGeoPoint checks that points are uniform by area, not by latitude
+func TestGeoPoint(t *testing.T) {
+	const n = 50000
+
+	sinLat, lng := make([]float64, n), make([]float64, n)
+	var polar int
+	for i := range sinLat {
+		c := GeoPoint()
+		require.True(t, c.Lat >= -90 && c.Lat <= 90 && c.Lng >= -180 && c.Lng < 180, "%v out of range", c)
+		sinLat[i], lng[i] = math.Sin(c.Lat*math.Pi/180), c.Lng
+		if math.Abs(c.Lat) > 60 {
+			polar++
+		}
+	}
+	assert.Less(t, ksStatistic(sinLat, uniformCDF(-1, 1)), ksCritical(n))
+	assert.Less(t, ksStatistic(lng, uniformCDF(-180, 180)), ksCritical(n))
+	// Naive sampling would put a third of the points beyond 60 degrees; only 13.4% of the area is
+	assert.InDelta(t, n*(1-math.Sin(math.Pi/3)), polar, 400)
+}
+
+// TestGeoPointInBox checks bounds, antimeridian crossing and uniformity
+func TestGeoPointInBox(t *testing.T) {
+	const n = 50000
+
+	sw, ne := LatLng{Lat: 30, Lng: -10}, LatLng{Lat: 70, Lng: 40}
+	sinLat := make([]float64, n)
+	for i := range sinLat {
+		c := GeoPointInBox(sw, ne)
+		require.True(t, c.Lat >= sw.Lat && c.Lat <= ne.Lat && c.Lng >= sw.Lng && c.Lng <= ne.Lng, "%v outside", c)
+		sinLat[i] = math.Sin(c.Lat * math.Pi / 180)
+	}
+	d := ksStatistic(sinLat, uniformCDF(math.Sin(30*math.Pi/180), math.Sin(70*math.Pi/180)))
+	assert.Less(t, d, ksCritical(n))
+
+	// Across the antimeridian
+	var east int
+	for i := 0; i < 10000; i++ {
+		c := GeoPointInBox(LatLng{Lat: -20, Lng: 170}, LatLng{Lat: -10, Lng: -175})
+		require.True(t, c.Lng >= 170 || c.Lng <= -175, "%v outside", c)
+		if c.Lng >= 170 {
+			east++
+		}
+	}
+	assert.InDelta(t, 10000*10.0/15, east, 300)
+
+	// A single point and the whole globe
+	assert.Equal(t, LatLng{Lat: 45, Lng: 7}, GeoPointInBox(LatLng{Lat: 45, Lng: 7}, LatLng{Lat: 45, Lng: 7}))
+	c, err := GeoPointInBoxSafe(LatLng{Lat: -90, Lng: -180}, LatLng{Lat: 90, Lng: 180})
+	require.NoError(t, err)
+	assert.True(t, c.Lng >= -180 && c.Lng < 180)
+
+	for _, box := range [][2]LatLng{
+		{{Lat: 10, Lng: 0}, {Lat: 0, Lng: 10}},
+		{{Lat: -91, Lng: 0}, {Lat: 0, Lng: 10}},
+		{{Lat: 0, Lng: 0}, {Lat: 10, Lng: 181}},
+		{{Lat: math.NaN(), Lng: 0}, {Lat: 10, Lng: 10}},
+	} {
+		_, err := GeoPointInBoxSafe(box[0], box[1])
+		assert.ErrorIs(t, err, ErrInvalidParameter, "GeoPointInBoxSafe(%v, %v)", box[0], box[1])
+	}
+}
+
+// TestGeoPointNear checks that distances from the center follow the area of
+// a spherical cap, including around a pole and across the antimeridian
+func TestGeoPointNear(t *testing.T) {
+	const n = 50000
+
+	for _, tc := range []struct {
+		center LatLng
+		radius float64
+	}{
+		{LatLng{Lat: 52.52, Lng: 13.405}, 5000},
+		{LatLng{Lat: 89.9, Lng: 0}, 200000},
+		{LatLng{Lat: -16, Lng: 179.99}, 1e6},
+		{LatLng{Lat: 0, Lng: 0}, 1e7},
+	} {
+		g := NewSeeded(uint64(tc.radius))
+		maxAngle := tc.radius / EarthRadius
+		dists := drawN(n, func() float64 {
+			c := g.GeoPointNear(tc.center, tc.radius)
+			require.True(t, c.Lat >= -90 && c.Lat <= 90 && c.Lng >= -180 && c.Lng < 180, "%v out of range", c)
+			return haversine(tc.center, c)
+		})
+		for _, d := range dists {
+			require.LessOrEqual(t, d, tc.radius*(1+1e-9)+1e-6)
+		}
+
+		cdf := func(d float64) float64 {
+			return math.Min(1, (1-math.Cos(d/EarthRadius))/(1-math.Cos(maxAngle)))
+		}
+		assert.Less(t, ksStatistic(dists, cdf), ksCritical(n), "GeoPointNear(%v, %g) is not uniform", tc.center, tc.radius)
+	}
+
+	// Zero radius returns the center; a huge radius covers the globe
+	c := GeoPointNear(LatLng{Lat: 10, Lng: 20}, 0)
+	assert.InDelta(t, 10, c.Lat, 1e-9)
+	assert.InDelta(t, 20, c.Lng, 1e-9)
+	_, err := GeoPointNearSafe(LatLng{}, 1e9)
+	assert.NoError(t, err)
+
+	assert.Equal(t, LatLng{}, GeoPointNear(LatLng{Lat: 10}, -1))
+	for _, radius := range []float64{-1, math.NaN(), math.Inf(1)} {
+		_, err := GeoPointNearSafe(LatLng{}, radius)
+		assert.ErrorIs(t, err, ErrInvalidParameter, "radius %g", radius)
+	}
+	_, err = GeoPointNearSafe(LatLng{Lat: 100}, 10)
+	assert.ErrorIs(t, err, ErrInvalidParameter)
+}
+
+// TestGeoGenerator verifies seeded reproducibility and strict-mode errors
+func TestGeoGenerator(t *testing.T) {
+	a, b := NewSeeded(12), NewSeeded(12)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, a.GeoPoint(), b.GeoPoint())
+		assert.Equal(t, a.GeoPointNear(LatLng{Lat: 1, Lng: 2}, 1000), b.GeoPointNear(LatLng{Lat: 1, Lng: 2}, 1000))
+	}
+
+	strict := NewGenerator(failingSource{}, WithStrict())
+	_, err := strict.GeoPointE()
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = strict.GeoPointInBoxSafe(LatLng{}, LatLng{Lat: 1, Lng: 1})
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = strict.GeoPointNearSafe(LatLng{}, 100)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	assert.Panics(t, func() { strict.GeoPoint() })
+}
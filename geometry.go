@@ -0,0 +1,525 @@
+package rand
+
+import (
+	"fmt"
+	"math"
+)
+
+// Point is a point in the plane
+type Point struct {
+	X, Y float64
+}
+
+// UnitVectorSafe returns a cryptographically secure random unit vector in dim
+// dimensions, uniformly distributed on the surface of the unit sphere. For
+// dim = 2 it is a uniform direction on the unit circle.
+//
+// It normalizes a vector of independent normal variates, whose distribution
+// is rotationally symmetric in any dimension.
+//
+// Parameters:
+//   - dim: the number of dimensions, which must be at least 1
+//
+// Returns:
+//   - A new slice of dim coordinates with Euclidean norm 1
+//   - An error wrapping ErrInvalidParameter if dim < 1,
+//     or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	dir, err := rand.UnitVectorSafe(3)
+//	if err != nil {
+//		// Handle error
+//	}
+func UnitVectorSafe(dim int) ([]float64, error) {
+	return defaultGenerator.UnitVectorSafe(dim)
+}
+
+// UnitVectorSafe is like the package-level UnitVectorSafe but draws from g.
+func (g *Generator) UnitVectorSafe(dim int) ([]float64, error) {
+	if dim < 1 {
+		return nil, invalidParameter("dim", float64(dim), ">= 1")
+	}
+
+	v := make([]float64, dim)
+	for {
+		var sumSq float64
+		for i := range v {
+			z, err := g.normFloat64()
+			if err != nil {
+				return nil, err
+			}
+			v[i] = z
+			sumSq += z * z
+		}
+		if sumSq == 0 {
+			continue // No direction; vanishingly rare
+		}
+
+		norm := math.Sqrt(sumSq)
+		for i := range v {
+			v[i] /= norm
+		}
+		return v, nil
+	}
+}
+
+// UnitVector returns a cryptographically secure random unit vector in dim
+// dimensions. It returns nil if dim < 1, matching RangeInt. In strict mode
+// (see SetStrict) it panics if the entropy source fails.
+//
+// Example:
+//
+//	dir := rand.UnitVector(3) // A uniform direction in space
+func UnitVector(dim int) []float64 {
+	return defaultGenerator.UnitVector(dim)
+}
+
+// UnitVector is like the package-level UnitVector but draws from g.
+func (g *Generator) UnitVector(dim int) []float64 {
+	return orZero(g.UnitVectorSafe(dim))
+}
+
+// InUnitBallSafe returns a cryptographically secure random point uniformly
+// distributed in the unit ball of dim dimensions, the points with Euclidean
+// norm at most 1. Scale the coordinates for a ball of another radius.
+//
+// It scales a uniform direction by U^(1/dim), since the volume within
+// radius r grows as r^dim.
+//
+// Parameters:
+//   - dim: the number of dimensions, which must be at least 1
+//
+// Returns:
+//   - A new slice of dim coordinates with Euclidean norm at most 1
+//   - An error wrapping ErrInvalidParameter if dim < 1,
+//     or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	p, err := rand.InUnitBallSafe(2) // A uniform point in the unit disk
+//	if err != nil {
+//		// Handle error
+//	}
+func InUnitBallSafe(dim int) ([]float64, error) {
+	return defaultGenerator.InUnitBallSafe(dim)
+}
+
+// InUnitBallSafe is like the package-level InUnitBallSafe but draws from g.
+func (g *Generator) InUnitBallSafe(dim int) ([]float64, error) {
+	v, err := g.UnitVectorSafe(dim)
+	if err != nil {
+		return nil, err
+	}
+	u, err := g.Float64E()
+	if err != nil {
+		return nil, err
+	}
+
+	r := math.Pow(u, 1/float64(dim))
+	for i := range v {
+		v[i] *= r
+	}
+	return v, nil
+}
+
+// InUnitBall returns a cryptographically secure random point uniformly
+// distributed in the unit ball of dim dimensions. It returns nil if dim < 1,
+// matching RangeInt. In strict mode (see SetStrict) it panics if the entropy
+// source fails.
+//
+// Example:
+//
+//	p := rand.InUnitBall(3)
+func InUnitBall(dim int) []float64 {
+	return defaultGenerator.InUnitBall(dim)
+}
+
+// InUnitBall is like the package-level InUnitBall but draws from g.
+func (g *Generator) InUnitBall(dim int) []float64 {
+	return orZero(g.InUnitBallSafe(dim))
+}
+
+// checkPoint reports whether p has finite coordinates
+func checkPoint(name string, p Point) error {
+	if !isFinite(p.X) {
+		return invalidParameter(name+".X", p.X, "finite")
+	}
+	if !isFinite(p.Y) {
+		return invalidParameter(name+".Y", p.Y, "finite")
+	}
+	return nil
+}
+
+// lerp returns a + u*(b-a) for u in [0, 1), kept within [a, b] and computed
+// without overflow when b-a exceeds math.MaxFloat64
+func lerp(a, b, u float64) float64 {
+	return math.Min(a*(1-u)+b*u, b)
+}
+
+// InRectangleSafe returns a cryptographically secure random point uniformly
+// distributed in the axis-aligned rectangle with corners min and max.
+//
+// Parameters:
+//   - min: the corner with the smallest coordinates, which must be finite
+//   - max: the corner with the largest coordinates, which must be finite and
+//     no smaller than min on either axis
+//
+// Returns:
+//   - A point with min.X <= X <= max.X and min.Y <= Y <= max.Y
+//   - An error wrapping ErrInvalidParameter if a corner is invalid,
+//     or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	p, err := rand.InRectangleSafe(rand.Point{X: 0, Y: 0}, rand.Point{X: 1920, Y: 1080})
+//	if err != nil {
+//		// Handle error
+//	}
+func InRectangleSafe(min, max Point) (Point, error) {
+	return defaultGenerator.InRectangleSafe(min, max)
+}
+
+// InRectangleSafe is like the package-level InRectangleSafe but draws from g.
+func (g *Generator) InRectangleSafe(min, max Point) (Point, error) {
+	if err := checkPoint("min", min); err != nil {
+		return Point{}, err
+	}
+	if err := checkPoint("max", max); err != nil {
+		return Point{}, err
+	}
+	if max.X < min.X || max.Y < min.Y {
+		return Point{}, fmt.Errorf("%w: max %v is below min %v", ErrInvalidParameter, max, min)
+	}
+
+	u, err := g.Float64E()
+	if err != nil {
+		return Point{}, err
+	}
+	v, err := g.Float64E()
+	if err != nil {
+		return Point{}, err
+	}
+	return Point{X: lerp(min.X, max.X, u), Y: lerp(min.Y, max.Y, v)}, nil
+}
+
+// InRectangle returns a cryptographically secure random point uniformly
+// distributed in the rectangle with corners min and max. It returns the zero
+// Point if a corner is invalid, matching RangeInt. In strict mode (see
+// SetStrict) it panics if the entropy source fails.
+//
+// Example:
+//
+//	p := rand.InRectangle(rand.Point{X: 0, Y: 0}, rand.Point{X: 1920, Y: 1080})
+func InRectangle(min, max Point) Point {
+	return defaultGenerator.InRectangle(min, max)
+}
+
+// InRectangle is like the package-level InRectangle but draws from g.
+func (g *Generator) InRectangle(min, max Point) Point {
+	return orZero(g.InRectangleSafe(min, max))
+}
+
+// triangle is the vertices of a triangle
+type triangle [3]Point
+
+// cross returns the z component of (b-a) x (c-a): positive if a, b, c turn
+// counterclockwise, and twice the signed area of the triangle
+func cross(a, b, c Point) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+// point returns the point at barycentric offsets u along ab and v along ac
+// of a uniform point in the triangle, folding the far half of the unit
+// square back into it
+func (t triangle) point(u, v float64) Point {
+	if u+v > 1 {
+		u, v = 1-u, 1-v
+	}
+	a, b, c := t[0], t[1], t[2]
+	return Point{
+		X: a.X + u*(b.X-a.X) + v*(c.X-a.X),
+		Y: a.Y + u*(b.Y-a.Y) + v*(c.Y-a.Y),
+	}
+}
+
+// InTriangleSafe returns a cryptographically secure random point uniformly
+// distributed in the triangle with vertices a, b and c, in any order.
+//
+// Parameters:
+//   - a, b, c: the vertices, which must have finite coordinates
+//
+// Returns:
+//   - A point inside the triangle or on its boundary
+//   - An error wrapping ErrInvalidParameter if a vertex is not finite,
+//     or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	p, err := rand.InTriangleSafe(rand.Point{X: 0, Y: 0}, rand.Point{X: 4, Y: 0}, rand.Point{X: 0, Y: 3})
+//	if err != nil {
+//		// Handle error
+//	}
+func InTriangleSafe(a, b, c Point) (Point, error) {
+	return defaultGenerator.InTriangleSafe(a, b, c)
+}
+
+// InTriangleSafe is like the package-level InTriangleSafe but draws from g.
+func (g *Generator) InTriangleSafe(a, b, c Point) (Point, error) {
+	for i, p := range []Point{a, b, c} {
+		if err := checkPoint(fmt.Sprintf("vertex[%d]", i), p); err != nil {
+			return Point{}, err
+		}
+	}
+	return g.inTriangle(triangle{a, b, c})
+}
+
+// inTriangle returns a uniform point in t
+func (g *Generator) inTriangle(t triangle) (Point, error) {
+	u, err := g.Float64E()
+	if err != nil {
+		return Point{}, err
+	}
+	v, err := g.Float64E()
+	if err != nil {
+		return Point{}, err
+	}
+	return t.point(u, v), nil
+}
+
+// InTriangle returns a cryptographically secure random point uniformly
+// distributed in the triangle with vertices a, b and c. It returns the zero
+// Point if a vertex is not finite, matching RangeInt. In strict mode (see
+// SetStrict) it panics if the entropy source fails.
+//
+// Example:
+//
+//	p := rand.InTriangle(rand.Point{X: 0, Y: 0}, rand.Point{X: 4, Y: 0}, rand.Point{X: 0, Y: 3})
+func InTriangle(a, b, c Point) Point {
+	return defaultGenerator.InTriangle(a, b, c)
+}
+
+// InTriangle is like the package-level InTriangle but draws from g.
+func (g *Generator) InTriangle(a, b, c Point) Point {
+	return orZero(g.InTriangleSafe(a, b, c))
+}
+
+// Polygon samples points uniformly from the interior of a simple polygon,
+// convex or not.
+//
+// It is built once by triangulating the polygon with ear clipping, which
+// takes O(n^2) time for typical polygons with n vertices. Each point is then
+// drawn in O(1) time by choosing a triangle in proportion to its area with a
+// WeightedChooser and sampling inside it.
+//
+// A Polygon is immutable and safe for concurrent use.
+type Polygon struct {
+	area      float64
+	triangles *WeightedChooser[triangle]
+}
+
+// NewPolygon returns a Polygon with the given vertices, in clockwise or
+// counterclockwise order. The last vertex connects back to the first.
+//
+// It returns an error wrapping ErrInvalidParameter if there are fewer than
+// three vertices, a coordinate is not finite, the area is zero, or the
+// polygon is not simple (its edges cross).
+//
+// Example:
+//
+//	// An L-shaped floor plan
+//	floor, err := rand.NewPolygon([]rand.Point{
+//		{X: 0, Y: 0}, {X: 20, Y: 0}, {X: 20, Y: 10}, {X: 10, Y: 10}, {X: 10, Y: 30}, {X: 0, Y: 30},
+//	})
+//	if err != nil {
+//		// Handle error
+//	}
+//	p := floor.Point()
+func NewPolygon(vertices []Point) (*Polygon, error) {
+	if len(vertices) < 3 {
+		return nil, fmt.Errorf("%w: polygon needs at least 3 vertices, got %d", ErrInvalidParameter, len(vertices))
+	}
+	for i, p := range vertices {
+		if err := checkPoint(fmt.Sprintf("vertices[%d]", i), p); err != nil {
+			return nil, err
+		}
+	}
+
+	// Shoelace formula; orient the polygon counterclockwise
+	var twiceArea float64
+	for i, p := range vertices {
+		q := vertices[(i+1)%len(vertices)]
+		twiceArea += p.X*q.Y - q.X*p.Y
+	}
+	order := make([]int, len(vertices))
+	for i := range order {
+		if twiceArea >= 0 {
+			order[i] = i
+		} else {
+			order[i] = len(vertices) - 1 - i
+		}
+	}
+	area := math.Abs(twiceArea) / 2
+	if area == 0 || math.IsInf(area, 0) {
+		return nil, fmt.Errorf("%w: polygon area is %v", ErrInvalidParameter, area)
+	}
+
+	triangles, ok := earClip(vertices, order)
+	areas := make([]float64, len(triangles))
+	var sum float64
+	for i, t := range triangles {
+		areas[i] = cross(t[0], t[1], t[2]) / 2
+		sum += areas[i]
+	}
+	// Ear clipping can also succeed on some self-intersecting polygons, whose
+	// overlapping triangles then cover more than the signed area
+	if !ok || math.Abs(sum-area) > 1e-9*area {
+		return nil, fmt.Errorf("%w: polygon is not simple", ErrInvalidParameter)
+	}
+
+	chooser, err := NewWeightedChooser(triangles, areas)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidParameter, err)
+	}
+	return &Polygon{area: area, triangles: chooser}, nil
+}
+
+// earClip triangulates the counterclockwise polygon vertices[order...] by
+// repeatedly cutting off an ear: a convex vertex whose triangle with its two
+// neighbours contains no other vertex. Triangles of zero area are dropped.
+// It reports false if no ear can be found, which means the polygon is not simple.
+func earClip(vertices []Point, order []int) ([]triangle, bool) {
+	var triangles []triangle
+
+	for i := 0; len(order) > 3; {
+		found := false
+		for tries := 0; tries < len(order); tries++ {
+			i %= len(order)
+			a := vertices[order[(i+len(order)-1)%len(order)]]
+			b := vertices[order[i]]
+			c := vertices[order[(i+1)%len(order)]]
+
+			if ear, area := isEar(vertices, order, i, a, b, c); ear {
+				if area > 0 {
+					triangles = append(triangles, triangle{a, b, c})
+				}
+				order = append(order[:i], order[i+1:]...)
+				found = true
+				break
+			}
+			i++
+		}
+		if !found {
+			return nil, false
+		}
+	}
+
+	a, b, c := vertices[order[0]], vertices[order[1]], vertices[order[2]]
+	if cross(a, b, c) > 0 {
+		triangles = append(triangles, triangle{a, b, c})
+	}
+	return triangles, true
+}
+
+// isEar reports whether the vertex at position i of order, with neighbours a
+// and c, can be clipped, and the doubled area of the triangle abc
+func isEar(vertices []Point, order []int, i int, a, b, c Point) (bool, float64) {
+	area := cross(a, b, c)
+	if area < 0 {
+		return false, area // Reflex vertex
+	}
+	if area == 0 {
+		return true, 0 // Collinear vertex; clipping it removes nothing
+	}
+
+	for j, k := range order {
+		if j == i || j == (i+len(order)-1)%len(order) || j == (i+1)%len(order) {
+			continue
+		}
+		p := vertices[k]
+		if p == a || p == b || p == c {
+			continue
+		}
+		if cross(a, b, p) >= 0 && cross(b, c, p) >= 0 && cross(c, a, p) >= 0 {
+			return false, area
+		}
+	}
+	return true, area
+}
+
+// Area returns the area of the polygon.
+func (p *Polygon) Area() float64 {
+	return p.area
+}
+
+// Point returns a point chosen uniformly from the polygon.
+// In strict mode (see SetStrict) it panics if the entropy source fails.
+func (p *Polygon) Point() Point {
+	return p.PointWith(defaultGenerator)
+}
+
+// PointE is like Point but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func (p *Polygon) PointE() (Point, error) {
+	return p.PointEWith(defaultGenerator)
+}
+
+// PointWith is like Point but draws from g.
+func (p *Polygon) PointWith(g *Generator) Point {
+	return must(p.PointEWith(g))
+}
+
+// PointEWith is like PointE but draws from g.
+func (p *Polygon) PointEWith(g *Generator) (Point, error) {
+	t, err := p.triangles.PickEWith(g)
+	if err != nil {
+		return Point{}, err
+	}
+	return g.inTriangle(t)
+}
+
+// InPolygonSafe returns a cryptographically secure random point uniformly
+// distributed in the simple polygon with the given vertices. It triangulates
+// the polygon on every call; use NewPolygon to draw many points from the same
+// polygon.
+//
+// Returns:
+//   - A point inside the polygon or on its boundary
+//   - An error wrapping ErrInvalidParameter if the polygon is invalid (see
+//     NewPolygon), or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	p, err := rand.InPolygonSafe([]rand.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 4}, {X: 0, Y: 4}})
+//	if err != nil {
+//		// Handle error
+//	}
+func InPolygonSafe(vertices []Point) (Point, error) {
+	return defaultGenerator.InPolygonSafe(vertices)
+}
+
+// InPolygonSafe is like the package-level InPolygonSafe but draws from g.
+func (g *Generator) InPolygonSafe(vertices []Point) (Point, error) {
+	p, err := NewPolygon(vertices)
+	if err != nil {
+		return Point{}, err
+	}
+	return p.PointEWith(g)
+}
+
+// InPolygon returns a cryptographically secure random point uniformly
+// distributed in the simple polygon with the given vertices. It returns the
+// zero Point if the polygon is invalid, matching RangeInt. In strict mode
+// (see SetStrict) it panics if the entropy source fails.
+//
+// Example:
+//
+//	p := rand.InPolygon([]rand.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 4}, {X: 0, Y: 4}})
+func InPolygon(vertices []Point) Point {
+	return defaultGenerator.InPolygon(vertices)
+}
+
+// InPolygon is like the package-level InPolygon but draws from g.
+func (g *Generator) InPolygon(vertices []Point) Point {
+	return orZero(g.InPolygonSafe(vertices))
+}
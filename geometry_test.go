@@ -0,0 +1,244 @@
+package rand
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// norm returns the Euclidean norm of v
+func norm(v []float64) float64 {
+	var sumSq float64
+	for _, x := range v {
+		sumSq += x * x
+	}
+	return math.Sqrt(sumSq)
+}
+
+// uniformCDF is the CDF of the uniform distribution on [lo, hi]
+func uniformCDF(lo, hi float64) func(float64) float64 {
+	return func(x float64) float64 {
+		return math.Max(0, math.Min(1, (x-lo)/(hi-lo)))
+	}
+}
+
+// TestUnitVector checks norms and the uniformity of directions
+func TestUnitVector(t *testing.T) {
+	const n = 50000
+
+	for _, dim := range []int{1, 2, 3, 10} {
+		for i := 0; i < 100; i++ {
+			v := UnitVector(dim)
+			require.Len(t, v, dim)
+			require.InDelta(t, 1, norm(v), 1e-12)
+		}
+	}
+
+	// On the 2-sphere each coordinate is uniform in [-1, 1] (Archimedes)
+	z := drawN(n, func() float64 { return UnitVector(3)[2] })
+	assert.Less(t, ksStatistic(z, uniformCDF(-1, 1)), ksCritical(n), "UnitVector(3) is not uniform")
+
+	// On the circle the angle is uniform
+	theta := drawN(n, func() float64 {
+		v := UnitVector(2)
+		return math.Atan2(v[1], v[0])
+	})
+	assert.Less(t, ksStatistic(theta, uniformCDF(-math.Pi, math.Pi)), ksCritical(n), "UnitVector(2) is not uniform")
+
+	assert.Nil(t, UnitVector(0))
+	_, err := UnitVectorSafe(-1)
+	assert.ErrorIs(t, err, ErrInvalidParameter)
+}
+
+// TestInUnitBall checks that the radius of points in the ball has CDF r^dim
+func TestInUnitBall(t *testing.T) {
+	const n = 50000
+
+	for _, dim := range []int{1, 2, 3, 7} {
+		g := NewSeeded(uint64(dim))
+		radii := drawN(n, func() float64 {
+			p := g.InUnitBall(dim)
+			require.Len(t, p, dim)
+			return norm(p)
+		})
+		d := ksStatistic(radii, func(r float64) float64 { return math.Pow(math.Min(r, 1), float64(dim)) })
+		assert.Less(t, d, ksCritical(n), "InUnitBall(%d) is not uniform", dim)
+	}
+
+	assert.Nil(t, InUnitBall(0))
+	_, err := InUnitBallSafe(0)
+	assert.ErrorIs(t, err, ErrInvalidParameter)
+}
+
+// TestInRectangle checks bounds and uniformity on each axis
+func TestInRectangle(t *testing.T) {
+	const n = 50000
+
+	min, max := Point{X: -3, Y: 10}, Point{X: 5, Y: 10.5}
+	xs, ys := make([]float64, n), make([]float64, n)
+	for i := range xs {
+		p := InRectangle(min, max)
+		require.True(t, p.X >= min.X && p.X <= max.X && p.Y >= min.Y && p.Y <= max.Y, "%v outside", p)
+		xs[i], ys[i] = p.X, p.Y
+	}
+	assert.Less(t, ksStatistic(xs, uniformCDF(min.X, max.X)), ksCritical(n))
+	assert.Less(t, ksStatistic(ys, uniformCDF(min.Y, max.Y)), ksCritical(n))
+
+	// Degenerate and huge rectangles
+	assert.Equal(t, Point{X: 1, Y: 2}, InRectangle(Point{X: 1, Y: 2}, Point{X: 1, Y: 2}))
+	p := InRectangle(Point{X: -math.MaxFloat64, Y: 0}, Point{X: math.MaxFloat64, Y: 0})
+	assert.False(t, math.IsInf(p.X, 0))
+
+	for _, c := range [][2]Point{
+		{{X: 1, Y: 0}, {X: 0, Y: 1}},
+		{{X: 0, Y: 1}, {X: 1, Y: 0}},
+		{{X: math.NaN(), Y: 0}, {X: 1, Y: 1}},
+		{{X: 0, Y: 0}, {X: 1, Y: math.Inf(1)}},
+	} {
+		_, err := InRectangleSafe(c[0], c[1])
+		assert.ErrorIs(t, err, ErrInvalidParameter, "InRectangleSafe(%v, %v)", c[0], c[1])
+	}
+}
+
+// TestInTriangle checks that points fall in each of the four medial
+// sub-triangles, of equal area, equally often
+func TestInTriangle(t *testing.T) {
+	const n = 100000
+
+	a, b, c := Point{X: 0, Y: 0}, Point{X: 4, Y: 1}, Point{X: 1, Y: 5}
+	mid := func(p, q Point) Point { return Point{X: (p.X + q.X) / 2, Y: (p.Y + q.Y) / 2} }
+	ab, bc, ca := mid(a, b), mid(b, c), mid(c, a)
+	subs := []triangle{{a, ab, ca}, {ab, b, bc}, {ca, bc, c}, {ab, bc, ca}}
+
+	counts := make(map[int64]int)
+	for i := 0; i < n; i++ {
+		p := InTriangle(a, c, b) // Either orientation works
+		for j, s := range subs {
+			if containsPoint(s[:], p) {
+				counts[int64(j)]++
+				break
+			}
+		}
+	}
+	stat, critical := chiSquareGOF(counts, n, 0, 3, func(int64) float64 { return 0.25 })
+	assert.Less(t, stat, critical, "InTriangle is not uniform: %v", counts)
+
+	assert.Equal(t, Point{}, InTriangle(a, b, Point{X: math.NaN()}))
+	_, err := InTriangleSafe(Point{X: math.Inf(-1)}, b, c)
+	assert.ErrorIs(t, err, ErrInvalidParameter)
+}
+
+// containsPoint reports whether p is inside the polygon or on its boundary,
+// by the even-odd rule with a tolerance for points on edges
+func containsPoint(polygon []Point, p Point) bool {
+	inside := false
+	for i, a := range polygon {
+		b := polygon[(i+1)%len(polygon)]
+		// On the edge
+		if math.Abs(cross(a, b, p)) < 1e-9 &&
+			p.X >= math.Min(a.X, b.X)-1e-9 && p.X <= math.Max(a.X, b.X)+1e-9 &&
+			p.Y >= math.Min(a.Y, b.Y)-1e-9 && p.Y <= math.Max(a.Y, b.Y)+1e-9 {
+			return true
+		}
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < a.X+(p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// TestPolygon checks triangulation and uniform sampling of concave polygons
+func TestPolygon(t *testing.T) {
+	const n = 60000
+
+	// An L made of three unit squares, clockwise, with a collinear vertex
+	l := []Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 1, Y: 2}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 0}, {X: 1, Y: 0}}
+	poly, err := NewPolygon(l)
+	require.NoError(t, err)
+	assert.InDelta(t, 3, poly.Area(), 1e-12)
+
+	counts := make(map[int64]int)
+	for i := 0; i < n; i++ {
+		p := poly.Point()
+		require.True(t, containsPoint(l, p), "%v outside the polygon", p)
+		switch {
+		case p.Y >= 1:
+			counts[0]++
+		case p.X < 1:
+			counts[1]++
+		default:
+			counts[2]++
+		}
+	}
+	stat, critical := chiSquareGOF(counts, n, 0, 2, func(int64) float64 { return 1.0 / 3 })
+	assert.Less(t, stat, critical, "Polygon is not uniform: %v", counts)
+
+	// A star with many reflex vertices
+	var star []Point
+	for i := 0; i < 20; i++ {
+		r := 1.0
+		if i%2 == 1 {
+			r = 0.3
+		}
+		angle := float64(i) * math.Pi / 10
+		star = append(star, Point{X: r * math.Cos(angle), Y: r * math.Sin(angle)})
+	}
+	poly, err = NewPolygon(star)
+	require.NoError(t, err)
+	assert.InDelta(t, 10*math.Sin(math.Pi/10)*0.3, poly.Area(), 1e-12)
+	for i := 0; i < 10000; i++ {
+		p := poly.Point()
+		require.True(t, containsPoint(star, p), "%v outside the star", p)
+	}
+
+	for name, vertices := range map[string][]Point{
+		"too few":   {{X: 0, Y: 0}, {X: 1, Y: 1}},
+		"collinear": {{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}},
+		"bowtie":    {{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 1}},
+		"crossing":  {{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 2, Y: -1}, {X: 0, Y: 4}},
+		"NaN":       {{X: 0, Y: 0}, {X: 1, Y: 0}, {X: math.NaN(), Y: 1}},
+	} {
+		_, err := NewPolygon(vertices)
+		assert.ErrorIs(t, err, ErrInvalidParameter, name)
+		assert.Equal(t, Point{}, InPolygon(vertices), name)
+	}
+
+	p, err := InPolygonSafe(l)
+	require.NoError(t, err)
+	assert.True(t, containsPoint(l, p))
+}
+
+// TestGeometryGenerator verifies seeded reproducibility and strict-mode errors
+func TestGeometryGenerator(t *testing.T) {
+	square := []Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}}
+	poly, err := NewPolygon(square)
+	require.NoError(t, err)
+
+	a, b := NewSeeded(11), NewSeeded(11)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, a.UnitVector(3), b.UnitVector(3))
+		assert.Equal(t, a.InUnitBall(2), b.InUnitBall(2))
+		assert.Equal(t, a.InTriangle(Point{}, Point{X: 1}, Point{Y: 1}), b.InTriangle(Point{}, Point{X: 1}, Point{Y: 1}))
+		assert.Equal(t, poly.PointWith(a), poly.PointWith(b))
+	}
+
+	strict := NewGenerator(failingSource{}, WithStrict())
+	_, err = strict.UnitVectorSafe(3)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = strict.InRectangleSafe(Point{}, Point{X: 1, Y: 1})
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = poly.PointEWith(strict)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	assert.Panics(t, func() { poly.PointWith(strict) })
+}
+
+func BenchmarkPolygonPoint(b *testing.B) {
+	poly, err := NewPolygon([]Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 2}, {X: 0, Y: 2}})
+	require.NoError(b, err)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		poly.Point()
+	}
+}
@@ -0,0 +1,299 @@
+package rand
+
+import (
+	"math"
+)
+
+// ZipfSafe returns a cryptographically secure Zipf-distributed value in [1, n]:
+// k is returned with probability proportional to 1/k^s. With s around 1 this
+// models the popularity of cache keys, words or web pages.
+//
+// It uses the rejection-inversion method of Hörmann and Derflinger, which
+// takes constant expected time for any n and, unlike math/rand's Zipf, also
+// accepts exponents s <= 1.
+//
+// Parameters:
+//   - n: the largest value, which must be at least 1
+//   - s: the exponent, which must be finite and positive
+//
+// Returns:
+//   - A value in [1, n]
+//   - An error wrapping ErrInvalidParameter if a parameter is out of its domain,
+//     or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	key, err := rand.ZipfSafe(1000000, 1.1) // Key 1 is the hottest
+//	if err != nil {
+//		// Handle error
+//	}
+func ZipfSafe(n int64, s float64) (int64, error) {
+	return defaultGenerator.ZipfSafe(n, s)
+}
+
+// ZipfSafe is like the package-level ZipfSafe but draws from g.
+func (g *Generator) ZipfSafe(n int64, s float64) (int64, error) {
+	if n < 1 {
+		return 0, invalidParameter("n", float64(n), ">= 1")
+	}
+	if !isFinite(s) || s <= 0 {
+		return 0, invalidParameter("s", s, "finite and > 0")
+	}
+	if n == 1 {
+		return 1, nil
+	}
+
+	z := zipfSampler{s: s}
+	hX1 := z.hIntegral(1.5) - 1
+	hN := z.hIntegral(float64(n) + 0.5)
+	squeeze := 2 - z.hIntegralInverse(z.hIntegral(2.5)-z.h(2))
+
+	for {
+		v, err := g.Float64E()
+		if err != nil {
+			return 0, err
+		}
+
+		// u is uniform in (hX1, hN]
+		u := hN + v*(hX1-hN)
+		x := z.hIntegralInverse(u)
+		k := math.Floor(x + 0.5)
+		if k < 1 {
+			k = 1
+		} else if k > float64(n) {
+			k = float64(n)
+		}
+
+		if k-x <= squeeze || u >= z.hIntegral(k+0.5)-z.h(k) {
+			return int64(k), nil
+		}
+	}
+}
+
+// Zipf returns a cryptographically secure Zipf-distributed value in [1, n]
+// with exponent s. It returns 0 if a parameter is invalid, matching RangeInt.
+// In strict mode (see SetStrict) it panics if the entropy source fails.
+//
+// Example:
+//
+//	key := rand.Zipf(1000000, 1.1)
+func Zipf(n int64, s float64) int64 {
+	return defaultGenerator.Zipf(n, s)
+}
+
+// Zipf is like the package-level Zipf but draws from g.
+func (g *Generator) Zipf(n int64, s float64) int64 {
+	return orZero(g.ZipfSafe(n, s))
+}
+
+// zipfSampler holds the hat function of rejection-inversion for exponent s:
+// h(x) = x^-s, bounding the probability of k on [k-0.5, k+0.5]
+type zipfSampler struct {
+	s float64
+}
+
+// h returns x^-s
+func (z zipfSampler) h(x float64) float64 {
+	return math.Exp(-z.s * math.Log(x))
+}
+
+// hIntegral returns an antiderivative of h, (x^(1-s) - 1)/(1-s), computed
+// stably for s close to 1
+func (z zipfSampler) hIntegral(x float64) float64 {
+	logX := math.Log(x)
+	return expm1Ratio((1-z.s)*logX) * logX
+}
+
+// hIntegralInverse is the inverse function of hIntegral
+func (z zipfSampler) hIntegralInverse(x float64) float64 {
+	t := x * (1 - z.s)
+	if t < -1 {
+		t = -1 // Guard against rounding just below the domain
+	}
+	return math.Exp(log1pRatio(t) * x)
+}
+
+// log1pRatio returns log(1+x)/x, continuous at 0
+func log1pRatio(x float64) float64 {
+	if math.Abs(x) > 1e-8 {
+		return math.Log1p(x) / x
+	}
+	return 1 - x*(0.5-x*(1.0/3-0.25*x))
+}
+
+// expm1Ratio returns (e^x - 1)/x, continuous at 0
+func expm1Ratio(x float64) float64 {
+	if math.Abs(x) > 1e-8 {
+		return math.Expm1(x) / x
+	}
+	return 1 + x*0.5*(1+x/3*(1+0.25*x))
+}
+
+// ParetoSafe returns a cryptographically secure Pareto-distributed float64
+// with scale xm and shape alpha: P(X > x) = (xm/x)^alpha for x >= xm.
+// Smaller shapes give heavier tails; the mean is finite only for alpha > 1.
+//
+// Parameters:
+//   - xm: the scale, the smallest possible value, which must be finite and positive
+//   - alpha: the shape, which must be finite and positive
+//
+// Returns:
+//   - A value in [xm, +Inf)
+//   - An error wrapping ErrInvalidParameter if a parameter is out of its domain,
+//     or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	size, err := rand.ParetoSafe(1024, 1.5) // Request size in bytes
+//	if err != nil {
+//		// Handle error
+//	}
+func ParetoSafe(xm, alpha float64) (float64, error) {
+	return defaultGenerator.ParetoSafe(xm, alpha)
+}
+
+// ParetoSafe is like the package-level ParetoSafe but draws from g.
+func (g *Generator) ParetoSafe(xm, alpha float64) (float64, error) {
+	if !isFinite(xm) || xm <= 0 {
+		return 0, invalidParameter("xm", xm, "finite and > 0")
+	}
+	if !isFinite(alpha) || alpha <= 0 {
+		return 0, invalidParameter("alpha", alpha, "finite and > 0")
+	}
+
+	// Inversion of the survival function at 1-u, which lies in (0, 1]
+	u, err := g.Float64E()
+	if err != nil {
+		return 0, err
+	}
+	return xm * math.Exp(-math.Log1p(-u)/alpha), nil
+}
+
+// Pareto returns a cryptographically secure Pareto-distributed float64 with
+// scale xm and shape alpha. It returns 0 if a parameter is invalid, matching
+// RangeInt. In strict mode (see SetStrict) it panics if the entropy source fails.
+//
+// Example:
+//
+//	size := rand.Pareto(1024, 1.5)
+func Pareto(xm, alpha float64) float64 {
+	return defaultGenerator.Pareto(xm, alpha)
+}
+
+// Pareto is like the package-level Pareto but draws from g.
+func (g *Generator) Pareto(xm, alpha float64) float64 {
+	return orZero(g.ParetoSafe(xm, alpha))
+}
+
+// LogNormalSafe returns a cryptographically secure log-normally distributed
+// float64: e^Y for a normal Y with mean mu and standard deviation sigma.
+// The median of the result is e^mu.
+//
+// Parameters:
+//   - mu: the mean of log(X), which must be finite
+//   - sigma: the standard deviation of log(X), which must be finite and non-negative
+//
+// Returns:
+//   - A value in [0, +Inf)
+//   - An error wrapping ErrInvalidParameter if a parameter is out of its domain,
+//     or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	latency, err := rand.LogNormalSafe(math.Log(80), 0.5) // Median 80ms
+//	if err != nil {
+//		// Handle error
+//	}
+func LogNormalSafe(mu, sigma float64) (float64, error) {
+	return defaultGenerator.LogNormalSafe(mu, sigma)
+}
+
+// LogNormalSafe is like the package-level LogNormalSafe but draws from g.
+func (g *Generator) LogNormalSafe(mu, sigma float64) (float64, error) {
+	if !isFinite(mu) {
+		return 0, invalidParameter("mu", mu, "finite")
+	}
+	if !isFinite(sigma) || sigma < 0 {
+		return 0, invalidParameter("sigma", sigma, "finite and >= 0")
+	}
+
+	z, err := g.normFloat64()
+	if err != nil {
+		return 0, err
+	}
+	return math.Exp(mu + sigma*z), nil
+}
+
+// LogNormal returns a cryptographically secure log-normally distributed
+// float64 with log-mean mu and log-standard deviation sigma. It returns 0 if
+// a parameter is invalid, matching RangeInt. In strict mode (see SetStrict)
+// it panics if the entropy source fails.
+//
+// Example:
+//
+//	latency := rand.LogNormal(math.Log(80), 0.5)
+func LogNormal(mu, sigma float64) float64 {
+	return defaultGenerator.LogNormal(mu, sigma)
+}
+
+// LogNormal is like the package-level LogNormal but draws from g.
+func (g *Generator) LogNormal(mu, sigma float64) float64 {
+	return orZero(g.LogNormalSafe(mu, sigma))
+}
+
+// WeibullSafe returns a cryptographically secure Weibull-distributed float64
+// with shape k and scale lambda: P(X > x) = exp(-(x/lambda)^k) for x >= 0.
+// A shape below 1 gives a heavy tail; a shape of 1 is the exponential
+// distribution with mean lambda.
+//
+// Parameters:
+//   - k: the shape, which must be finite and positive
+//   - lambda: the scale, which must be finite and positive
+//
+// Returns:
+//   - A value in [0, +Inf)
+//   - An error wrapping ErrInvalidParameter if a parameter is out of its domain,
+//     or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	lifetime, err := rand.WeibullSafe(1.5, 1000) // Hours until failure
+//	if err != nil {
+//		// Handle error
+//	}
+func WeibullSafe(k, lambda float64) (float64, error) {
+	return defaultGenerator.WeibullSafe(k, lambda)
+}
+
+// WeibullSafe is like the package-level WeibullSafe but draws from g.
+func (g *Generator) WeibullSafe(k, lambda float64) (float64, error) {
+	if !isFinite(k) || k <= 0 {
+		return 0, invalidParameter("k", k, "finite and > 0")
+	}
+	if !isFinite(lambda) || lambda <= 0 {
+		return 0, invalidParameter("lambda", lambda, "finite and > 0")
+	}
+
+	// Inversion of the survival function at 1-u, which lies in (0, 1]
+	u, err := g.Float64E()
+	if err != nil {
+		return 0, err
+	}
+	return lambda * math.Pow(-math.Log1p(-u), 1/k), nil
+}
+
+// Weibull returns a cryptographically secure Weibull-distributed float64 with
+// shape k and scale lambda. It returns 0 if a parameter is invalid, matching
+// RangeInt. In strict mode (see SetStrict) it panics if the entropy source fails.
+//
+// Example:
+//
+//	lifetime := rand.Weibull(1.5, 1000)
+func Weibull(k, lambda float64) float64 {
+	return defaultGenerator.Weibull(k, lambda)
+}
+
+// Weibull is like the package-level Weibull but draws from g.
+func (g *Generator) Weibull(k, lambda float64) float64 {
+	return orZero(g.WeibullSafe(k, lambda))
+}
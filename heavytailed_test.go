@@ -0,0 +1,157 @@
+package rand
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestZipf validates the Zipf distribution against its exact pmf
+func TestZipf(t *testing.T) {
+	const draws = 200000
+
+	for _, tc := range []struct {
+		n int64
+		s float64
+	}{
+		{2, 1}, {10, 0.5}, {100, 1}, {1000, 1.1}, {50, 2.5}, {1 << 40, 1.2},
+	} {
+		g := NewSeeded(uint64(tc.n) + uint64(tc.s*10))
+		counts := countDraws(draws, func() int64 { return g.Zipf(tc.n, tc.s) })
+
+		for k := range counts {
+			assert.True(t, k >= 1 && k <= tc.n, "Zipf(%d, %g) returned %d", tc.n, tc.s, k)
+		}
+
+		// Normalize over the bins that can hold any mass; the tail beyond
+		// them is folded into the pooled bin of chiSquareGOF
+		hi := tc.n
+		if hi > 100000 {
+			hi = 100000
+		}
+		var norm float64
+		for k := int64(1); k <= hi; k++ {
+			norm += math.Pow(float64(k), -tc.s)
+		}
+		if hi < tc.n {
+			// Euler–Maclaurin estimate of the remaining sum
+			a, b := float64(hi)+0.5, float64(tc.n)+0.5
+			norm += (math.Pow(b, 1-tc.s) - math.Pow(a, 1-tc.s)) / (1 - tc.s)
+		}
+		pmf := func(k int64) float64 { return math.Pow(float64(k), -tc.s) / norm }
+
+		stat, critical := chiSquareGOF(counts, draws, 1, hi, pmf)
+		assert.Less(t, stat, critical, "Zipf(%d, %g) failed the chi-squared test", tc.n, tc.s)
+	}
+
+	assert.Equal(t, int64(1), Zipf(1, 3))
+	assert.Equal(t, int64(0), Zipf(0, 1))
+	for _, tc := range []struct {
+		n int64
+		s float64
+	}{
+		{0, 1}, {-5, 1}, {10, 0}, {10, -1}, {10, math.NaN()}, {10, math.Inf(1)},
+	} {
+		_, err := ZipfSafe(tc.n, tc.s)
+		assert.ErrorIs(t, err, ErrInvalidParameter, "ZipfSafe(%d, %g)", tc.n, tc.s)
+	}
+}
+
+// TestPareto validates the Pareto distribution with a KS test
+func TestPareto(t *testing.T) {
+	const n = 100000
+
+	for _, alpha := range []float64{0.5, 1.16, 3} {
+		g := NewSeeded(uint64(alpha * 100))
+		samples := drawN(n, func() float64 { return g.Pareto(2, alpha) })
+		for _, x := range samples {
+			if x < 2 {
+				t.Fatalf("Pareto(2, %g) returned %g below the scale", alpha, x)
+			}
+		}
+
+		d := ksStatistic(samples, func(x float64) float64 {
+			return 1 - math.Pow(2/x, alpha)
+		})
+		assert.Less(t, d, ksCritical(n), "Pareto(2, %g) failed the KS test", alpha)
+	}
+
+	assert.Equal(t, 0.0, Pareto(0, 1))
+	for _, p := range [][2]float64{{0, 1}, {-1, 1}, {1, 0}, {1, -2}, {math.Inf(1), 1}, {1, math.NaN()}} {
+		_, err := ParetoSafe(p[0], p[1])
+		assert.ErrorIs(t, err, ErrInvalidParameter, "ParetoSafe(%g, %g)", p[0], p[1])
+	}
+}
+
+// TestLogNormal validates the log-normal distribution with a KS test
+func TestLogNormal(t *testing.T) {
+	const n = 100000
+
+	samples := drawN(n, func() float64 { return LogNormal(1.5, 0.75) })
+	for _, x := range samples {
+		if x <= 0 {
+			t.Fatalf("LogNormal returned non-positive %g", x)
+		}
+	}
+
+	logCDF := normalCDF(1.5, 0.75)
+	d := ksStatistic(samples, func(x float64) float64 { return logCDF(math.Log(x)) })
+	assert.Less(t, d, ksCritical(n), "LogNormal failed the KS test")
+
+	assert.Equal(t, math.E, LogNormal(1, 0))
+	for _, p := range [][2]float64{{math.NaN(), 1}, {math.Inf(-1), 1}, {0, -1}, {0, math.Inf(1)}} {
+		_, err := LogNormalSafe(p[0], p[1])
+		assert.ErrorIs(t, err, ErrInvalidParameter, "LogNormalSafe(%g, %g)", p[0], p[1])
+	}
+}
+
+// TestWeibull validates the Weibull distribution with a KS test
+func TestWeibull(t *testing.T) {
+	const n = 100000
+
+	for _, k := range []float64{0.5, 1, 3.5} {
+		g := NewSeeded(uint64(k * 10))
+		samples := drawN(n, func() float64 { return g.Weibull(k, 10) })
+
+		d := ksStatistic(samples, func(x float64) float64 {
+			return -math.Expm1(-math.Pow(x/10, k))
+		})
+		assert.Less(t, d, ksCritical(n), "Weibull(%g, 10) failed the KS test", k)
+	}
+
+	assert.Equal(t, 0.0, Weibull(-1, 1))
+	for _, p := range [][2]float64{{0, 1}, {-1, 1}, {1, 0}, {1, -2}, {math.NaN(), 1}, {1, math.Inf(1)}} {
+		_, err := WeibullSafe(p[0], p[1])
+		assert.ErrorIs(t, err, ErrInvalidParameter, "WeibullSafe(%g, %g)", p[0], p[1])
+	}
+}
+
+// TestHeavyTailedGenerator verifies seeded reproducibility and strict-mode errors
+func TestHeavyTailedGenerator(t *testing.T) {
+	a, b := NewSeeded(9), NewSeeded(9)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, a.Zipf(1000, 1.1), b.Zipf(1000, 1.1))
+		assert.Equal(t, a.Pareto(1, 1.5), b.Pareto(1, 1.5))
+		assert.Equal(t, a.LogNormal(0, 1), b.LogNormal(0, 1))
+		assert.Equal(t, a.Weibull(2, 1), b.Weibull(2, 1))
+	}
+
+	strict := NewGenerator(failingSource{}, WithStrict())
+	_, err := strict.ZipfSafe(100, 1)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = strict.ParetoSafe(1, 1)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = strict.LogNormalSafe(0, 1)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = strict.WeibullSafe(1, 1)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	assert.Panics(t, func() { strict.Zipf(100, 1) })
+	assert.Equal(t, 0.0, strict.Pareto(-1, 1))
+}
+
+func BenchmarkZipf(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Zipf(1000000, 1.1)
+	}
+}
@@ -1,80 +1,119 @@
 package rand
 
 import (
-	"math"
+	"math/bits"
 )
 
-// randUint64 generates a cryptographically secure random uint64.
-// It uses crypto/rand as the primary source and falls back to math/rand if necessary.
-func randUint64() uint64 {
-	maxBig := getBigInt()
-	defer putBigInt(maxBig)
-
-	maxBig.SetUint64(math.MaxUint64)
-	if result, ok := secureRandomBigInt(maxBig); ok {
-		return result.Uint64()
-	}
-
-	// Fallback to pseudo-random generation
-	return getFallbackRand().Uint64()
-}
-
-// randInt64 generates a cryptographically secure random int64.
-// It uses crypto/rand as the primary source and falls back to math/rand if necessary.
-func randInt64() int64 {
-	maxBig := getBigInt()
-	defer putBigInt(maxBig)
-
-	maxBig.SetInt64(math.MaxInt64)
-	if result, ok := secureRandomBigInt(maxBig); ok {
-		return result.Int64()
-	}
-
-	// Fallback to pseudo-random generation
-	return getFallbackRand().Int63()
-}
-
-// Int32 returns a cryptographically secure random int32 value in the range [0, math.MaxInt32).
+// Int32 returns a cryptographically secure random int32 value in the range [0, math.MaxInt32].
 // The distribution is uniform across the entire range.
 //
 // Example:
 //
 //	n := rand.Int32() // Returns a value like 1234567890
 func Int32() int32 {
-	return int32(randInt64() >> 32)
+	return defaultGenerator.Int32()
+}
+
+// Int32 is like the package-level Int32 but draws from g.
+func (g *Generator) Int32() int32 {
+	return must(g.Int32E())
+}
+
+// Int32E is like Int32 but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func Int32E() (int32, error) {
+	return defaultGenerator.Int32E()
+}
+
+// Int32E is like the package-level Int32E but draws from g.
+func (g *Generator) Int32E() (int32, error) {
+	// The top 31 bits give every non-negative int32 with equal probability
+	n, err := g.uint64()
+	return int32(n >> 33), err
 }
 
-// Int64 returns a cryptographically secure random int64 value in the range [0, math.MaxInt64).
+// Int64 returns a cryptographically secure random int64 value in the range [0, math.MaxInt64].
 // The distribution is uniform across the entire range.
 //
 // Example:
 //
 //	n := rand.Int64() // Returns a value like 1234567890123456789
 func Int64() int64 {
-	return randInt64()
+	return defaultGenerator.Int64()
 }
 
-// Uint32 returns a cryptographically secure random uint32 value in the range [0, math.MaxUint32).
+// Int64 is like the package-level Int64 but draws from g.
+func (g *Generator) Int64() int64 {
+	return must(g.Int64E())
+}
+
+// Int64E is like Int64 but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func Int64E() (int64, error) {
+	return defaultGenerator.Int64E()
+}
+
+// Int64E is like the package-level Int64E but draws from g.
+func (g *Generator) Int64E() (int64, error) {
+	// The top 63 bits give every non-negative int64 with equal probability
+	n, err := g.uint64()
+	return int64(n >> 1), err
+}
+
+// Uint32 returns a cryptographically secure random uint32 value in the range [0, math.MaxUint32].
 // The distribution is uniform across the entire range.
 //
 // Example:
 //
 //	n := rand.Uint32() // Returns a value like 2345678901
 func Uint32() uint32 {
-	return uint32(randUint64() >> 32)
+	return defaultGenerator.Uint32()
+}
+
+// Uint32 is like the package-level Uint32 but draws from g.
+func (g *Generator) Uint32() uint32 {
+	return must(g.Uint32E())
 }
 
-// Uint64 returns a cryptographically secure random uint64 value in the range [0, math.MaxUint64).
+// Uint32E is like Uint32 but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func Uint32E() (uint32, error) {
+	return defaultGenerator.Uint32E()
+}
+
+// Uint32E is like the package-level Uint32E but draws from g.
+func (g *Generator) Uint32E() (uint32, error) {
+	n, err := g.uint64()
+	return uint32(n >> 32), err
+}
+
+// Uint64 returns a cryptographically secure random uint64 value in the range [0, math.MaxUint64].
 // The distribution is uniform across the entire range.
 //
 // Example:
 //
 //	n := rand.Uint64() // Returns a value like 12345678901234567890
 func Uint64() uint64 {
-	return randUint64()
+	return defaultGenerator.Uint64()
+}
+
+// Uint64 is like the package-level Uint64 but draws from g.
+func (g *Generator) Uint64() uint64 {
+	return must(g.Uint64E())
 }
 
-// Int returns a cryptographically secure random int value in the range [0, math.MaxInt).
+// Uint64E is like Uint64 but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func Uint64E() (uint64, error) {
+	return defaultGenerator.Uint64E()
+}
+
+// Uint64E is like the package-level Uint64E but draws from g.
+func (g *Generator) Uint64E() (uint64, error) {
+	return g.uint64()
+}
+
+// Int returns a cryptographically secure random int value in the range [0, math.MaxInt].
 // The distribution is uniform across the entire range.
 // This function is platform-dependent as int size varies by architecture.
 //
@@ -82,15 +121,29 @@ func Uint64() uint64 {
 //
 //	n := rand.Int() // Returns a value like 1234567890123456
 func Int() int {
-	n := randInt64()
-	// Ensure the value fits in the platform's int type
-	if n <= math.MaxInt {
-		return int(n)
-	}
-	return int(n % math.MaxInt)
+	return defaultGenerator.Int()
+}
+
+// Int is like the package-level Int but draws from g.
+func (g *Generator) Int() int {
+	return must(g.IntE())
+}
+
+// IntE is like Int but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func IntE() (int, error) {
+	return defaultGenerator.IntE()
 }
 
-// Uint returns a cryptographically secure random uint value in the range [0, math.MaxUint).
+// IntE is like the package-level IntE but draws from g.
+func (g *Generator) IntE() (int, error) {
+	// Keep the top bits.UintSize-1 bits so every non-negative int is equally
+	// likely on both 32-bit and 64-bit platforms
+	n, err := g.uint64()
+	return int(n >> (65 - bits.UintSize)), err
+}
+
+// Uint returns a cryptographically secure random uint value in the range [0, math.MaxUint].
 // The distribution is uniform across the entire range.
 // This function is platform-dependent as uint size varies by architecture.
 //
@@ -98,10 +151,22 @@ func Int() int {
 //
 //	n := rand.Uint() // Returns a value like 1234567890123456
 func Uint() uint {
-	n := randUint64()
-	// Ensure the value fits in the platform's uint type
-	if n <= math.MaxUint {
-		return uint(n)
-	}
-	return uint(n % math.MaxUint)
+	return defaultGenerator.Uint()
+}
+
+// Uint is like the package-level Uint but draws from g.
+func (g *Generator) Uint() uint {
+	return must(g.UintE())
+}
+
+// UintE is like Uint but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func UintE() (uint, error) {
+	return defaultGenerator.UintE()
+}
+
+// UintE is like the package-level UintE but draws from g.
+func (g *Generator) UintE() (uint, error) {
+	n, err := g.uint64()
+	return uint(n >> (64 - bits.UintSize)), err
 }
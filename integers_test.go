@@ -2,6 +2,7 @@ package rand
 
 import (
 	"math"
+	"math/bits"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,7 +18,7 @@ func TestInt32(t *testing.T) {
 
 		// Test bounds
 		assert.GreaterOrEqual(t, n, int32(0), "Int32() should return non-negative values")
-		assert.Less(t, n, int32(math.MaxInt32), "Int32() should be less than MaxInt32")
+		assert.LessOrEqual(t, n, int32(math.MaxInt32), "Int32() should be at most MaxInt32")
 	}
 
 	// Test uniqueness with multiple calls
@@ -45,7 +46,7 @@ func TestInt64(t *testing.T) {
 
 		// Test bounds
 		assert.GreaterOrEqual(t, n, int64(0), "Int64() should return non-negative values")
-		assert.Less(t, n, int64(math.MaxInt64), "Int64() should be less than MaxInt64")
+		assert.LessOrEqual(t, n, int64(math.MaxInt64), "Int64() should be at most MaxInt64")
 	}
 
 	// Test uniqueness
@@ -73,7 +74,7 @@ func TestInt(t *testing.T) {
 
 		// Test bounds
 		assert.GreaterOrEqual(t, n, 0, "Int() should return non-negative values")
-		assert.Less(t, n, math.MaxInt, "Int() should be less than MaxInt")
+		assert.LessOrEqual(t, n, math.MaxInt, "Int() should be at most MaxInt")
 	}
 }
 
@@ -86,7 +87,7 @@ func TestUint32(t *testing.T) {
 
 		// Test bounds
 		assert.GreaterOrEqual(t, n, uint32(0), "Uint32() should return non-negative values")
-		assert.Less(t, n, uint32(math.MaxUint32), "Uint32() should be less than MaxUint32")
+		assert.LessOrEqual(t, n, uint32(math.MaxUint32), "Uint32() should be at most MaxUint32")
 	}
 
 	// Test uniqueness
@@ -113,7 +114,7 @@ func TestUint64(t *testing.T) {
 
 		// Test bounds
 		assert.GreaterOrEqual(t, n, uint64(0), "Uint64() should return non-negative values")
-		assert.Less(t, n, uint64(math.MaxUint64), "Uint64() should be less than MaxUint64")
+		assert.LessOrEqual(t, n, uint64(math.MaxUint64), "Uint64() should be at most MaxUint64")
 	}
 
 	// Test uniqueness
@@ -140,7 +141,7 @@ func TestUint(t *testing.T) {
 
 		// Test bounds
 		assert.GreaterOrEqual(t, n, uint(0), "Uint() should return non-negative values")
-		assert.Less(t, n, uint(math.MaxUint), "Uint() should be less than MaxUint")
+		assert.LessOrEqual(t, n, uint(math.MaxUint), "Uint() should be at most MaxUint")
 	}
 }
 
@@ -228,10 +229,126 @@ func TestConcurrency(t *testing.T) {
 
 		// Basic bounds check
 		require.GreaterOrEqual(t, n, int32(0))
-		require.Less(t, n, int32(math.MaxInt32))
+		require.LessOrEqual(t, n, int32(math.MaxInt32))
 	}
 
 	// Should have generated many unique values
 	assert.Greater(t, len(values), goroutines*iterations/2,
 		"Concurrent generation should produce many unique values")
 }
+
+// assertBitsUniform checks that each of the low width bits of the samples is set
+// about half the time. A biased or truncated generator fails for its high bits.
+func assertBitsUniform(t *testing.T, name string, width int, samples []uint64) {
+	t.Helper()
+
+	n := float64(len(samples))
+	sigma := math.Sqrt(n) / 2
+	for bit := 0; bit < width; bit++ {
+		ones := 0
+		for _, v := range samples {
+			ones += int(v >> uint(bit) & 1)
+		}
+		assert.InDelta(t, n/2, float64(ones), 6*sigma,
+			"%s: bit %d set %d times out of %d", name, bit, ones, len(samples))
+	}
+}
+
+// chiSquare256 returns the chi-square statistic of the byte values over 256 buckets
+func chiSquare256(values []uint8) float64 {
+	var counts [256]float64
+	for _, v := range values {
+		counts[v]++
+	}
+	expected := float64(len(values)) / 256
+	var chi float64
+	for _, c := range counts {
+		chi += (c - expected) * (c - expected) / expected
+	}
+	return chi
+}
+
+// TestBitUniformity validates that every integer function uses all of its bits evenly
+func TestBitUniformity(t *testing.T) {
+	const samples = 20000
+
+	collect := func(fn func() uint64) []uint64 {
+		out := make([]uint64, samples)
+		for i := range out {
+			out[i] = fn()
+		}
+		return out
+	}
+
+	assertBitsUniform(t, "Int32", 31, collect(func() uint64 { return uint64(Int32()) }))
+	assertBitsUniform(t, "Int64", 63, collect(func() uint64 { return uint64(Int64()) }))
+	assertBitsUniform(t, "Uint32", 32, collect(func() uint64 { return uint64(Uint32()) }))
+	assertBitsUniform(t, "Uint64", 64, collect(func() uint64 { return Uint64() }))
+	assertBitsUniform(t, "Int", bits.UintSize-1, collect(func() uint64 { return uint64(Int()) }))
+	assertBitsUniform(t, "Uint", bits.UintSize, collect(func() uint64 { return uint64(Uint()) }))
+}
+
+// TestHighLowByteDistribution runs a chi-square test on the lowest and highest byte
+// of each integer function. The critical value 330 for 255 degrees of freedom
+// corresponds to p < 0.001.
+func TestHighLowByteDistribution(t *testing.T) {
+	const samples = 50000
+	const critical = 330.0
+
+	testCases := []struct {
+		name  string
+		width int
+		fn    func() uint64
+	}{
+		{"Int32", 31, func() uint64 { return uint64(Int32()) }},
+		{"Int64", 63, func() uint64 { return uint64(Int64()) }},
+		{"Uint32", 32, func() uint64 { return uint64(Uint32()) }},
+		{"Uint64", 64, func() uint64 { return Uint64() }},
+	}
+
+	for _, tc := range testCases {
+		low := make([]uint8, samples)
+		high := make([]uint8, samples)
+		for i := 0; i < samples; i++ {
+			v := tc.fn()
+			low[i] = uint8(v)
+			high[i] = uint8(v >> uint(tc.width-8))
+		}
+
+		assert.Less(t, chiSquare256(low), critical, "%s low byte is not uniform", tc.name)
+		assert.Less(t, chiSquare256(high), critical, "%s high byte is not uniform", tc.name)
+	}
+}
+
+// TestFullRange validates that the maximum values are reachable
+func TestFullRange(t *testing.T) {
+	// Uint64 used to sample [0, MaxUint64); the top bit must now be set about half the time
+	topBitSet := 0
+	for i := 0; i < 1000; i++ {
+		if Uint64()>>63 == 1 {
+			topBitSet++
+		}
+	}
+	assert.InDelta(t, 500, topBitSet, 100)
+
+	// A seeded source producing all ones must map to the maximum of each type
+	g := NewGenerator(constantSource(math.MaxUint64))
+	assert.Equal(t, int32(math.MaxInt32), g.Int32())
+	assert.Equal(t, int64(math.MaxInt64), g.Int64())
+	assert.Equal(t, uint32(math.MaxUint32), g.Uint32())
+	assert.Equal(t, uint64(math.MaxUint64), g.Uint64())
+	assert.Equal(t, math.MaxInt, g.Int())
+	assert.Equal(t, uint(math.MaxUint), g.Uint())
+}
+
+// constantSource always returns the same 64 bits
+type constantSource uint64
+
+func (c constantSource) Uint64() uint64 { return uint64(c) }
+
+func (c constantSource) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(uint64(c) >> (8 * uint(i%8)))
+	}
+	return len(p), nil
+}
@@ -0,0 +1,364 @@
+package rand
+
+import (
+	"bufio"
+	_ "embed" // for the EFF wordlists
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInvalidWordlist is returned when a wordlist cannot be parsed or is unfit for passphrases
+var ErrInvalidWordlist = errors.New("invalid wordlist")
+
+// The EFF wordlists, published by the Electronic Frontier Foundation under
+// CC BY 3.0 US (https://www.eff.org/dice), are embedded in their original
+// Diceware format and parsed on first use
+var (
+	//go:embed wordlists/eff_large_wordlist.txt
+	effLargeText string
+
+	//go:embed wordlists/eff_short_wordlist_2_0.txt
+	effShortText string
+
+	effLarge, effShort         *Wordlist
+	effLargeOnce, effShortOnce sync.Once
+)
+
+// Wordlist is a list of distinct words to build passphrases from. It is
+// immutable and safe for concurrent use. A usable Wordlist comes from
+// NewWordlist, EFFLargeWordlist or EFFShortWordlist; the zero value is empty.
+type Wordlist struct {
+	words []string
+
+	// capitalizable counts the words that change when their first letter is
+	// capitalized, which is what CapitalizeRandom adds entropy to
+	capitalizable int
+}
+
+// NewWordlist reads a wordlist from r, one word per line. Lines may also be in
+// the Diceware format of the EFF lists, a dice roll, whitespace and the word,
+// as in "11111	abacus". Blank lines are skipped.
+//
+// It returns an error wrapping ErrInvalidWordlist if a line holds more than
+// one word, a word appears twice (ignoring case) or there are fewer than two
+// words, and the error of r if reading fails.
+//
+// Example:
+//
+//	f, err := os.Open("words.txt")
+//	if err != nil {
+//		// Handle error
+//	}
+//	defer f.Close()
+//	list, err := rand.NewWordlist(f)
+func NewWordlist(r io.Reader) (*Wordlist, error) {
+	w := &Wordlist{}
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && isDiceRoll(fields[0]) {
+			fields = fields[1:]
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) > 1 {
+			return nil, fmt.Errorf("%w: line %d holds more than one word", ErrInvalidWordlist, line)
+		}
+
+		word := fields[0]
+		if !utf8.ValidString(word) {
+			return nil, fmt.Errorf("%w: line %d is not valid UTF-8", ErrInvalidWordlist, line)
+		}
+		// Words differing only in case would coincide once capitalized
+		key := strings.ToLower(word)
+		if seen[key] {
+			return nil, fmt.Errorf("%w: %q on line %d appears twice", ErrInvalidWordlist, word, line)
+		}
+		seen[key] = true
+
+		w.words = append(w.words, word)
+		if capitalize(word) != word {
+			w.capitalizable++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(w.words) < 2 {
+		return nil, fmt.Errorf("%w: %d words, need at least 2", ErrInvalidWordlist, len(w.words))
+	}
+	return w, nil
+}
+
+// EFFLargeWordlist returns the EFF large wordlist: 7,776 common words of 3 to
+// 9 letters, one for every roll of five dice, giving 12.9 bits per word.
+func EFFLargeWordlist() *Wordlist {
+	effLargeOnce.Do(func() {
+		effLarge = mustParseWordlist(effLargeText)
+	})
+	return effLarge
+}
+
+// EFFShortWordlist returns the second EFF short wordlist: 1,296 memorable
+// words, one for every roll of four dice, giving 10.3 bits per word. No two
+// words share their first three letters, so typing those is enough.
+func EFFShortWordlist() *Wordlist {
+	effShortOnce.Do(func() {
+		effShort = mustParseWordlist(effShortText)
+	})
+	return effShort
+}
+
+// Len returns the number of words in the list
+func (w *Wordlist) Len() int {
+	return len(w.words)
+}
+
+// mustParseWordlist parses an embedded wordlist, which is known to be valid
+func mustParseWordlist(text string) *Wordlist {
+	w, err := NewWordlist(strings.NewReader(text))
+	if err != nil {
+		panic(err)
+	}
+	return w
+}
+
+// isDiceRoll reports whether s is a Diceware roll such as "11111"
+func isDiceRoll(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// capitalize returns word with its first letter in upper case
+func capitalize(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(r)) + word[size:]
+}
+
+// Capitalization selects how a PassphrasePolicy capitalizes its words
+type Capitalization int
+
+const (
+	// CapitalizeNone leaves words as they appear in the wordlist
+	CapitalizeNone Capitalization = iota
+
+	// CapitalizeFirst capitalizes the first letter of every word, as in "Correct-Horse"
+	CapitalizeFirst
+
+	// CapitalizeAll writes every word in upper case, as in "CORRECT-HORSE"
+	CapitalizeAll
+
+	// CapitalizeRandom capitalizes the first letter of each word with
+	// probability 1/2, adding a bit of entropy per word
+	CapitalizeRandom
+)
+
+// PassphrasePolicy describes Diceware passphrases such as
+// "correct-horse-battery-staple": words drawn uniformly and independently
+// from a wordlist, optionally capitalized and with random digits appended.
+//
+// Example:
+//
+//	policy := rand.PassphrasePolicy{
+//		Words:          5,
+//		Separator:      " ",
+//		Capitalization: rand.CapitalizeFirst,
+//		Digits:         1,
+//		Wordlist:       rand.EFFShortWordlist(),
+//	}
+//	phrase, err := policy.Generate() // Like "Shrimp Dresser Goldfish8 Acrobat Lakefront"
+type PassphrasePolicy struct {
+	// Words is the number of words, which must be at least 1
+	Words int
+
+	// Separator is placed between words and may be empty
+	Separator string
+
+	// Capitalization selects how words are capitalized
+	Capitalization Capitalization
+
+	// Digits is the number of words, chosen at random, that get a random
+	// digit appended; it must not exceed Words
+	Digits int
+
+	// Wordlist is the list to draw words from, EFFLargeWordlist if nil
+	Wordlist *Wordlist
+}
+
+// DefaultPassphrasePolicy returns a policy for six hyphen-separated words from
+// the EFF large wordlist, as the EFF recommends, carrying 77.5 bits of entropy.
+//
+// Example:
+//
+//	policy := rand.DefaultPassphrasePolicy()
+//	policy.Capitalization = rand.CapitalizeFirst
+//	phrase, err := policy.Generate()
+func DefaultPassphrasePolicy() PassphrasePolicy {
+	return PassphrasePolicy{Words: 6, Separator: "-"}
+}
+
+// Generate returns a cryptographically secure passphrase following the policy.
+//
+// It returns an error wrapping ErrInvalidPolicy if the policy is inconsistent,
+// one wrapping ErrInvalidWordlist if its Wordlist is empty, and one wrapping
+// ErrEntropyUnavailable if the entropy source fails in strict mode (see
+// SetStrict).
+func (p PassphrasePolicy) Generate() (string, error) {
+	return p.GenerateWith(defaultGenerator)
+}
+
+// GenerateWith is like Generate but draws from g.
+func (p PassphrasePolicy) GenerateWith(g *Generator) (string, error) {
+	list, err := p.wordlist()
+	if err != nil {
+		return "", err
+	}
+
+	words := make([]string, p.Words)
+	for i := range words {
+		idx, err := g.uint64n(uint64(len(list.words)))
+		if err != nil {
+			return "", err
+		}
+		word := list.words[idx]
+
+		switch p.Capitalization {
+		case CapitalizeFirst:
+			word = capitalize(word)
+		case CapitalizeAll:
+			word = strings.ToUpper(word)
+		case CapitalizeRandom:
+			upper, err := g.BoolE()
+			if err != nil {
+				return "", err
+			}
+			if upper {
+				word = capitalize(word)
+			}
+		}
+		words[i] = word
+	}
+
+	if p.Digits > 0 {
+		positions := make([]int, p.Words)
+		for i := range positions {
+			positions[i] = i
+		}
+		chosen, err := SampleSafeWith(g, positions, p.Digits)
+		if err != nil {
+			return "", err
+		}
+		for _, i := range chosen {
+			d, err := g.uint64n(10)
+			if err != nil {
+				return "", err
+			}
+			words[i] += string(rune('0' + d))
+		}
+	}
+
+	return strings.Join(words, p.Separator), nil
+}
+
+// Entropy returns the entropy in bits of the passphrases generated by the
+// policy: log2(n) per word for a wordlist of n words, plus up to a bit per word
+// for CapitalizeRandom, plus the choice of words and values for Digits.
+//
+// The count assumes that different choices give different passphrases. An
+// empty separator, or one that also occurs within words as "-" does in a few
+// EFF words like "t-shirt", can make some coincide and lower the true entropy
+// slightly.
+//
+// It returns an error wrapping ErrInvalidPolicy if the policy is inconsistent,
+// and one wrapping ErrInvalidWordlist if its Wordlist is empty.
+//
+// Example:
+//
+//	bits, err := rand.DefaultPassphrasePolicy().Entropy() // 77.5 bits
+func (p PassphrasePolicy) Entropy() (float64, error) {
+	list, err := p.wordlist()
+	if err != nil {
+		return 0, err
+	}
+
+	n := float64(len(list.words))
+	perWord := math.Log2(n)
+	if p.Capitalization == CapitalizeRandom {
+		// A coin flip only counts for words that capitalizing changes
+		perWord += float64(list.capitalizable) / n
+	}
+	bits := float64(p.Words) * perWord
+
+	if p.Digits > 0 {
+		// log2 of the binomial coefficient choosing the words that get a digit
+		a, _ := math.Lgamma(float64(p.Words + 1))
+		b, _ := math.Lgamma(float64(p.Digits + 1))
+		c, _ := math.Lgamma(float64(p.Words - p.Digits + 1))
+		bits += (a-b-c)/math.Ln2 + float64(p.Digits)*math.Log2(10)
+	}
+	return bits, nil
+}
+
+// wordlist validates the policy and returns the wordlist to draw from
+func (p PassphrasePolicy) wordlist() (*Wordlist, error) {
+	if p.Words < 1 {
+		return nil, fmt.Errorf("%w: %d words, must be at least 1", ErrInvalidPolicy, p.Words)
+	}
+	if p.Digits < 0 || p.Digits > p.Words {
+		return nil, fmt.Errorf("%w: %d digits, must be in [0, %d]", ErrInvalidPolicy, p.Digits, p.Words)
+	}
+	if p.Capitalization < CapitalizeNone || p.Capitalization > CapitalizeRandom {
+		return nil, fmt.Errorf("%w: unknown capitalization %d", ErrInvalidPolicy, p.Capitalization)
+	}
+	if p.Wordlist == nil {
+		return EFFLargeWordlist(), nil
+	}
+	if len(p.Wordlist.words) < 2 {
+		return nil, fmt.Errorf("%w: %d words, need at least 2", ErrInvalidWordlist, len(p.Wordlist.words))
+	}
+	return p.Wordlist, nil
+}
+
+// Passphrase generates a cryptographically secure Diceware passphrase of the
+// given number of words from the EFF large wordlist, separated by hyphens.
+// Every word adds 12.9 bits of entropy. It returns an empty string if
+// words <= 0; use a PassphrasePolicy for other settings.
+//
+// Example:
+//
+//	phrase := rand.Passphrase(6) // Like "cobweb-uncross-unvisited-pelican-gravy-sprawl"
+func Passphrase(words int) string {
+	return defaultGenerator.Passphrase(words)
+}
+
+// Passphrase is like the package-level Passphrase but draws from g.
+func (g *Generator) Passphrase(words int) string {
+	return must(g.PassphraseE(words))
+}
+
+// PassphraseE is like Passphrase but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func PassphraseE(words int) (string, error) {
+	return defaultGenerator.PassphraseE(words)
+}
+
+// PassphraseE is like the package-level PassphraseE but draws from g.
+func (g *Generator) PassphraseE(words int) (string, error) {
+	if words <= 0 {
+		return "", nil
+	}
+	return PassphrasePolicy{Words: words, Separator: "-"}.GenerateWith(g)
+}
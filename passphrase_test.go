@@ -0,0 +1,254 @@
+package rand
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEFFWordlists checks the embedded lists against their published properties
+func TestEFFWordlists(t *testing.T) {
+	large, short := EFFLargeWordlist(), EFFShortWordlist()
+	require.Equal(t, 7776, large.Len())
+	require.Equal(t, 1296, short.Len())
+	assert.Same(t, large, EFFLargeWordlist())
+
+	assert.Equal(t, "abacus", large.words[0])
+	assert.Equal(t, "zoom", large.words[7775])
+	assert.Equal(t, "aardvark", short.words[0])
+	assert.Equal(t, "zucchini", short.words[1295])
+
+	for _, w := range large.words {
+		require.Regexp(t, `^[a-z-]{3,9}$`, w)
+	}
+	assert.Equal(t, large.Len(), large.capitalizable)
+
+	prefixes := make(map[string]bool)
+	for _, w := range short.words {
+		require.False(t, prefixes[w[:3]], "%q shares its prefix", w)
+		prefixes[w[:3]] = true
+	}
+}
+
+// TestNewWordlist checks both line formats and the rejected inputs
+func TestNewWordlist(t *testing.T) {
+	w, err := NewWordlist(strings.NewReader("11111\tapple\n\n11112  banana \r\ncherry\n3d\nÉclair\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apple", "banana", "cherry", "3d", "Éclair"}, w.words)
+	assert.Equal(t, 3, w.capitalizable)
+
+	for name, text := range map[string]string{
+		"empty":          "",
+		"one word":       "11111 apple\n\n",
+		"two words":      "apple pie\nbanana\n",
+		"three fields":   "11111 apple pie\nbanana\n",
+		"duplicate":      "apple\nbanana\napple\n",
+		"duplicate case": "apple\nApple\n",
+		"invalid UTF-8":  "apple\n\xff\n",
+	} {
+		_, err := NewWordlist(strings.NewReader(text))
+		assert.ErrorIs(t, err, ErrInvalidWordlist, name)
+	}
+
+	_, err = NewWordlist(failingSource{})
+	assert.EqualError(t, err, "entropy unavailable")
+}
+
+// TestPassphrasePolicy checks the shape of generated passphrases
+func TestPassphrasePolicy(t *testing.T) {
+	large := make(map[string]bool)
+	for _, w := range EFFLargeWordlist().words {
+		large[w] = true
+	}
+
+	for name, p := range map[string]PassphrasePolicy{
+		"plain":      {Words: 6, Separator: "/"},
+		"first":      {Words: 4, Separator: " ", Capitalization: CapitalizeFirst},
+		"all":        {Words: 3, Separator: ".", Capitalization: CapitalizeAll},
+		"random":     {Words: 8, Separator: "_", Capitalization: CapitalizeRandom},
+		"digits":     {Words: 5, Separator: "~", Digits: 2},
+		"all digits": {Words: 3, Separator: "+", Digits: 3},
+	} {
+		for i := 0; i < 200; i++ {
+			s, err := p.Generate()
+			require.NoError(t, err, name)
+			words := strings.Split(s, p.Separator)
+			require.Len(t, words, p.Words, "%s: %q", name, s)
+
+			digits := 0
+			for _, w := range words {
+				if last := w[len(w)-1]; last >= '0' && last <= '9' {
+					digits++
+					w = w[:len(w)-1]
+				}
+				switch p.Capitalization {
+				case CapitalizeNone:
+					require.True(t, large[w], "%s: %q", name, w)
+				case CapitalizeFirst:
+					require.True(t, unicode.IsUpper(rune(w[0])), "%s: %q", name, w)
+					require.True(t, large[strings.ToLower(w[:1])+w[1:]], "%s: %q", name, w)
+				case CapitalizeAll:
+					require.True(t, large[strings.ToLower(w)] && w == strings.ToUpper(w), "%s: %q", name, w)
+				case CapitalizeRandom:
+					require.True(t, large[strings.ToLower(w[:1])+w[1:]], "%s: %q", name, w)
+				}
+			}
+			require.Equal(t, p.Digits, digits, "%s: %q", name, s)
+		}
+	}
+
+	// Random capitalization uses both forms
+	seen := make(map[bool]int)
+	for i := 0; i < 100; i++ {
+		s, err := PassphrasePolicy{Words: 1, Capitalization: CapitalizeRandom}.Generate()
+		require.NoError(t, err)
+		seen[unicode.IsUpper(rune(s[0]))]++
+	}
+	assert.Len(t, seen, 2)
+
+	// Other wordlists and empty separators: the same draws with a space, which
+	// no EFF word contains, show the words that were joined
+	short := make(map[string]bool)
+	for _, w := range EFFShortWordlist().words {
+		short[w] = true
+	}
+	p := PassphrasePolicy{Words: 7, Wordlist: EFFShortWordlist()}
+	for seed := uint64(0); seed < 50; seed++ {
+		joined, err := p.GenerateWith(NewSeeded(seed))
+		require.NoError(t, err)
+		p.Separator = " "
+		spaced, err := p.GenerateWith(NewSeeded(seed))
+		require.NoError(t, err)
+		p.Separator = ""
+
+		words := strings.Split(spaced, " ")
+		require.Len(t, words, 7, spaced)
+		for _, w := range words {
+			require.True(t, short[w], "%q", w)
+		}
+		assert.Equal(t, strings.Join(words, ""), joined)
+	}
+}
+
+// TestPassphrasePolicyUniform enumerates a tiny wordlist and checks that every
+// passphrase is equally likely and that Entropy counts them exactly
+func TestPassphrasePolicyUniform(t *testing.T) {
+	list, err := NewWordlist(strings.NewReader("ab\ncd\n9x\n"))
+	require.NoError(t, err)
+	p := PassphrasePolicy{Words: 2, Separator: " ", Capitalization: CapitalizeRandom, Digits: 1, Wordlist: list}
+
+	// Each word renders 5 ways ("ab", "Ab", "cd", "Cd", "9x"), with "9x"
+	// drawn as often as the others together
+	forms := map[string]float64{"ab": 1.0 / 6, "Ab": 1.0 / 6, "cd": 1.0 / 6, "Cd": 1.0 / 6, "9x": 1.0 / 3}
+	probs := make(map[string]float64)
+	for a, pa := range forms {
+		for b, pb := range forms {
+			for d := '0'; d <= '9'; d++ {
+				probs[a+string(d)+" "+b] += pa * pb / 20
+				probs[a+" "+b+string(d)] += pa * pb / 20
+			}
+		}
+	}
+	require.Len(t, probs, 500)
+
+	var entropy float64
+	index := make(map[string]int64)
+	pmf := make([]float64, 0, len(probs))
+	for s, q := range probs {
+		entropy -= q * math.Log2(q)
+		index[s] = int64(len(pmf))
+		pmf = append(pmf, q)
+	}
+	bits, err := p.Entropy()
+	require.NoError(t, err)
+	assert.InDelta(t, entropy, bits, 1e-9)
+
+	g := NewSeeded(23)
+	const draws = 200000
+	counts := make(map[int64]int)
+	for i := 0; i < draws; i++ {
+		s, err := p.GenerateWith(g)
+		require.NoError(t, err)
+		k, ok := index[s]
+		require.True(t, ok, "unexpected passphrase %q", s)
+		counts[k]++
+	}
+	stat, critical := chiSquareGOF(counts, draws, 0, int64(len(pmf))-1, func(k int64) float64 { return pmf[k] })
+	assert.Less(t, stat, critical)
+}
+
+// TestPassphraseEntropy checks entropies of common policies
+func TestPassphraseEntropy(t *testing.T) {
+	bits, err := DefaultPassphrasePolicy().Entropy()
+	require.NoError(t, err)
+	assert.InDelta(t, 77.55, bits, 0.01)
+
+	bits, err = PassphrasePolicy{Words: 6, Capitalization: CapitalizeRandom}.Entropy()
+	require.NoError(t, err)
+	assert.InDelta(t, 6*math.Log2(7776)+6, bits, 1e-9)
+
+	bits, err = PassphrasePolicy{Words: 6, Capitalization: CapitalizeAll, Wordlist: EFFShortWordlist()}.Entropy()
+	require.NoError(t, err)
+	assert.InDelta(t, 6*math.Log2(1296), bits, 1e-9)
+
+	// Two of five words get a digit: C(5, 2) * 10^2 choices
+	bits, err = PassphrasePolicy{Words: 5, Digits: 2}.Entropy()
+	require.NoError(t, err)
+	assert.InDelta(t, 5*math.Log2(7776)+math.Log2(1000), bits, 1e-9)
+}
+
+// TestPassphrasePolicyInvalid checks that inconsistent policies are rejected
+func TestPassphrasePolicyInvalid(t *testing.T) {
+	for name, p := range map[string]PassphrasePolicy{
+		"zero value":      {},
+		"negative digits": {Words: 3, Digits: -1},
+		"too many digits": {Words: 3, Digits: 4},
+		"capitalization":  {Words: 3, Capitalization: Capitalization(9)},
+	} {
+		_, err := p.Generate()
+		assert.ErrorIs(t, err, ErrInvalidPolicy, name)
+		_, err = p.Entropy()
+		assert.ErrorIs(t, err, ErrInvalidPolicy, name)
+	}
+
+	// The zero Wordlist has nothing to draw from
+	p := PassphrasePolicy{Words: 3, Wordlist: &Wordlist{}}
+	_, err := p.Generate()
+	assert.ErrorIs(t, err, ErrInvalidWordlist)
+	_, err = p.Entropy()
+	assert.ErrorIs(t, err, ErrInvalidWordlist)
+}
+
+// TestPassphrase verifies the convenience functions, seeding and strict mode
+func TestPassphrase(t *testing.T) {
+	// A few EFF words such as "t-shirt" contain a hyphen themselves
+	s := Passphrase(6)
+	assert.Regexp(t, `^[a-z-]+$`, s)
+	assert.GreaterOrEqual(t, strings.Count(s, "-"), 5)
+	assert.Empty(t, Passphrase(0))
+	assert.Empty(t, Passphrase(-1))
+
+	assert.Equal(t, NewSeeded(21).Passphrase(4), NewSeeded(21).Passphrase(4))
+	p := PassphrasePolicy{Words: 6, Capitalization: CapitalizeRandom, Digits: 2}
+	x, err := p.GenerateWith(NewSeeded(22))
+	require.NoError(t, err)
+	y, err := p.GenerateWith(NewSeeded(22))
+	require.NoError(t, err)
+	assert.Equal(t, x, y)
+
+	strict := NewGenerator(failingSource{}, WithStrict())
+	_, err = strict.PassphraseE(4)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = p.GenerateWith(strict)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+}
+
+func BenchmarkPassphrase(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Passphrase(6)
+	}
+}
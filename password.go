@@ -0,0 +1,561 @@
+package rand
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInvalidPolicy is returned when a PasswordPolicy is inconsistent or cannot be satisfied
+var ErrInvalidPolicy = errors.New("invalid password policy")
+
+// PasswordSymbols is the default symbol set of a PasswordPolicy: the printable
+// ASCII punctuation without quotes, backslash and backtick, which tend to
+// need escaping in shells, configuration files and URLs
+const PasswordSymbols = "!#$%&()*+,-./:;<=>?@[]^_{|}~"
+
+// MaxPasswordLength is the longest password a PasswordPolicy may describe.
+// Long passwords with large minimums are further limited by the size of the
+// counting table behind Generate; see PasswordPolicy.Length.
+const MaxPasswordLength = 128
+
+// maxPasswordAttempts bounds the whole-password rejection used by NoSequential
+const maxPasswordAttempts = 10000
+
+// maxCompiledPolicies and maxCompiledBytes bound the cache of counting
+// tables by number and by total size. No single table may exceed
+// maxCompiledBytes, so every table can be cached.
+const (
+	maxCompiledPolicies = 64
+	maxCompiledBytes    = 32 << 20
+)
+
+var (
+	// compiledPolicies caches the counting tables of recently used policies,
+	// which are immutable once built
+	compiledPolicies      = make(map[PasswordPolicy]*compiledPolicy)
+	compiledPoliciesBytes int
+	compiledPoliciesMu    sync.Mutex
+)
+
+// PasswordPolicy describes the passwords to generate: their length, how many
+// characters of each class they must contain, which characters to avoid and
+// which patterns to forbid.
+//
+// The characters are drawn from four classes: uppercase letters (A-Z),
+// lowercase letters (a-z), digits (0-9) and symbols (PasswordSymbols unless
+// Symbols is set). Generate picks uniformly among all strings that satisfy
+// every rule, so no password is more likely than another.
+//
+// Example:
+//
+//	policy := rand.PasswordPolicy{
+//		Length:      16,
+//		MinUpper:    2,
+//		MinDigits:   2,
+//		MinSymbols:  1,
+//		VisibleOnly: true,
+//		NoRepeat:    true,
+//	}
+//	password, err := policy.Generate()
+type PasswordPolicy struct {
+	// Length is the number of characters, from 1 to MaxPasswordLength.
+	//
+	// Generate counts passwords exactly with a table of Length+1 rows, each
+	// holding (MinUpper+1)*(MinLower+1)*(MinDigits+1)*(MinSymbols+1) counts,
+	// five times as many with NoRepeat, of up to Length*log2(characters) bits.
+	// The table must fit in 32 MiB: with the default character sets and
+	// minimums of 4 per class, that allows a length of up to 91 with NoRepeat
+	// and up to MaxPasswordLength without.
+	Length int
+
+	// MinUpper, MinLower, MinDigits and MinSymbols are the minimum number of
+	// characters of each class; together they must not exceed Length. Each
+	// minimum m multiplies the size of the counting table by m+1; see Length.
+	MinUpper, MinLower, MinDigits, MinSymbols int
+
+	// Symbols replaces PasswordSymbols as the symbol set when not empty.
+	// It must not contain letters, digits or whitespace.
+	Symbols string
+
+	// NoSymbols leaves symbols out entirely
+	NoSymbols bool
+
+	// Exclude lists characters that never appear, e.g. ones a legacy system rejects
+	Exclude string
+
+	// NoRepeat forbids the same character twice in a row, as in "aa"
+	NoRepeat bool
+
+	// NoSequential forbids three characters in ascending or descending order
+	// of code point, as in "abc", "CBA" or "123"
+	NoSequential bool
+
+	// VisibleOnly restricts letters and digits to VisibleLetters, leaving out
+	// 0, O, I, l and 1, which are easily confused when read
+	VisibleOnly bool
+}
+
+// DefaultPasswordPolicy returns a policy for 16-character passwords with at
+// least one character of each class.
+//
+// Example:
+//
+//	policy := rand.DefaultPasswordPolicy()
+//	policy.Length = 20
+//	password, err := policy.Generate()
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{Length: 16, MinUpper: 1, MinLower: 1, MinDigits: 1, MinSymbols: 1}
+}
+
+// Generate returns a cryptographically secure password chosen uniformly among
+// all strings that satisfy the policy.
+//
+// The per-class minimums and NoRepeat are handled exactly: Generate counts the
+// valid completions of every prefix and picks each character in proportion to
+// them, so the rules never force a biased fix-up or shuffle. NoSequential is
+// enforced by drawing whole passwords until one has no sequence, which keeps
+// the result uniform among those that pass. The counting table is built on
+// the first use of a policy and cached.
+//
+// It returns an error wrapping ErrInvalidPolicy if the policy is inconsistent
+// or no password satisfies it, and an error wrapping ErrEntropyUnavailable if
+// the entropy source fails in strict mode (see SetStrict).
+func (p PasswordPolicy) Generate() (string, error) {
+	return p.GenerateWith(defaultGenerator)
+}
+
+// GenerateWith is like Generate but draws from g.
+func (p PasswordPolicy) GenerateWith(g *Generator) (string, error) {
+	c, err := p.compiled()
+	if err != nil {
+		return "", err
+	}
+
+	for attempt := 0; attempt < maxPasswordAttempts; attempt++ {
+		password, err := c.generate(g)
+		if err != nil {
+			return "", err
+		}
+		if !p.NoSequential || !hasSequence(password) {
+			return string(password), nil
+		}
+	}
+	return "", fmt.Errorf("%w: no password without sequences found in %d attempts", ErrInvalidPolicy, maxPasswordAttempts)
+}
+
+// Entropy returns the entropy in bits of the passwords generated by the
+// policy: the base-2 logarithm of the number of passwords that satisfy it,
+// all of which Generate picks with equal probability. Every rule lowers the
+// entropy below that of an unconstrained string of the same length, though
+// usually by little.
+//
+// It returns an error wrapping ErrInvalidPolicy if the policy is inconsistent
+// or no password satisfies it.
+//
+// Example:
+//
+//	bits, err := rand.DefaultPasswordPolicy().Entropy() // About 104 bits
+func (p PasswordPolicy) Entropy() (float64, error) {
+	c, err := p.compiled()
+	if err != nil {
+		return 0, err
+	}
+	if !p.NoSequential {
+		return log2Big(&c.count[0][c.state(0, c.start())]), nil
+	}
+
+	n := c.countWithoutSequences()
+	if n.Sign() == 0 {
+		return 0, fmt.Errorf("%w: no password satisfies the policy", ErrInvalidPolicy)
+	}
+	return log2Big(n), nil
+}
+
+// passwordClass is a character class of a policy with its remaining characters
+// and minimum count
+type passwordClass struct {
+	name  string
+	chars []rune
+	min   int
+}
+
+// compiledPolicy holds the counting table of a policy.
+//
+// A state records, for every class, how many of its characters the prefix
+// holds, capped at the class minimum, and with NoRepeat also the class of the
+// last character: a repeat is only possible within that class, and every
+// character of a class leaves the same number of completions. count[i][s] is
+// the number of valid ways to complete a prefix of length i in state s.
+type compiledPolicy struct {
+	classes  []passwordClass
+	length   int
+	radix    []int // capped counts are digits in a mixed-radix state index
+	counts   int   // number of capped count combinations
+	lasts    int   // number of last-class values tracked: classes+1 with NoRepeat, else 1
+	noRepeat bool
+	count    [][]big.Int
+	size     int // approximate memory held by count, in bytes
+}
+
+// classes returns the character classes of the policy after exclusions
+func (p PasswordPolicy) classes() ([]passwordClass, error) {
+	upper, lower, digits := "ABCDEFGHIJKLMNOPQRSTUVWXYZ", "abcdefghijklmnopqrstuvwxyz", "0123456789"
+	symbols := PasswordSymbols
+	if p.Symbols != "" {
+		symbols = p.Symbols
+	}
+	if p.NoSymbols {
+		symbols = ""
+	}
+	for _, r := range symbols {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) || r == utf8.RuneError {
+			return nil, fmt.Errorf("%w: Symbols contains %q", ErrInvalidPolicy, r)
+		}
+	}
+
+	keep := func(set string) []rune {
+		var chars []rune
+		seen := make(map[rune]bool)
+		for _, r := range set {
+			if seen[r] || strings.ContainsRune(p.Exclude, r) {
+				continue
+			}
+			if p.VisibleOnly && !isVisible(r) {
+				continue
+			}
+			seen[r] = true
+			chars = append(chars, r)
+		}
+		return chars
+	}
+
+	classes := []passwordClass{
+		{name: "uppercase letters", chars: keep(upper), min: p.MinUpper},
+		{name: "lowercase letters", chars: keep(lower), min: p.MinLower},
+		{name: "digits", chars: keep(digits), min: p.MinDigits},
+		{name: "symbols", chars: keep(symbols), min: p.MinSymbols},
+	}
+
+	var total, minTotal int
+	for _, c := range classes {
+		if c.min < 0 {
+			return nil, fmt.Errorf("%w: negative minimum for %s", ErrInvalidPolicy, c.name)
+		}
+		if c.min > 0 && len(c.chars) == 0 {
+			return nil, fmt.Errorf("%w: %d %s required but none are allowed", ErrInvalidPolicy, c.min, c.name)
+		}
+		total += len(c.chars)
+		minTotal += c.min
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("%w: every character is excluded", ErrInvalidPolicy)
+	}
+	if minTotal > p.Length {
+		return nil, fmt.Errorf("%w: minimums add up to %d, more than the length %d", ErrInvalidPolicy, minTotal, p.Length)
+	}
+	return classes, nil
+}
+
+// compiled returns the counting table of the policy from the cache, building it if needed
+func (p PasswordPolicy) compiled() (*compiledPolicy, error) {
+	compiledPoliciesMu.Lock()
+	c, ok := compiledPolicies[p]
+	compiledPoliciesMu.Unlock()
+	if ok {
+		return c, nil
+	}
+
+	c, err := p.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	compiledPoliciesMu.Lock()
+	defer compiledPoliciesMu.Unlock()
+	if len(compiledPolicies) >= maxCompiledPolicies || compiledPoliciesBytes+c.size > maxCompiledBytes {
+		compiledPolicies = make(map[PasswordPolicy]*compiledPolicy)
+		compiledPoliciesBytes = 0
+	}
+	compiledPolicies[p] = c
+	compiledPoliciesBytes += c.size
+	return c, nil
+}
+
+// compile validates the policy and builds its counting table
+func (p PasswordPolicy) compile() (*compiledPolicy, error) {
+	if p.Length < 1 || p.Length > MaxPasswordLength {
+		return nil, fmt.Errorf("%w: length is %d, must be in [1, %d]", ErrInvalidPolicy, p.Length, MaxPasswordLength)
+	}
+	classes, err := p.classes()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &compiledPolicy{classes: classes, length: p.Length, counts: 1, lasts: 1, noRepeat: p.NoRepeat}
+	if p.NoRepeat {
+		c.lasts = len(classes) + 1
+	}
+
+	// Every count is below chars^Length, which bounds its size in words
+	chars := 0
+	for _, class := range classes {
+		chars += len(class.chars)
+	}
+	countBytes := 32 + (p.Length*bits.Len(uint(chars))/bits.UintSize+1)*bits.UintSize/8
+	maxCounts := maxCompiledBytes / countBytes / (p.Length + 1) / c.lasts
+	for _, class := range classes {
+		c.radix = append(c.radix, class.min+1)
+		c.counts *= class.min + 1
+		// Checked as the product grows so that it cannot overflow
+		if c.counts > maxCounts {
+			return nil, fmt.Errorf("%w: length %d with these minimums needs a counting table over %d MiB",
+				ErrInvalidPolicy, p.Length, maxCompiledBytes>>20)
+		}
+	}
+
+	// Fill the table backwards from the complete passwords: only the state
+	// in which every minimum is met counts as valid
+	states := c.counts * c.lasts
+	c.count = make([][]big.Int, p.Length+1)
+	for i := range c.count {
+		c.count[i] = make([]big.Int, states)
+	}
+	for last := 0; last < c.lasts; last++ {
+		c.count[p.Length][c.state(c.counts-1, last)].SetInt64(1)
+	}
+
+	var term big.Int
+	for i := p.Length - 1; i >= 0; i-- {
+		for counts := 0; counts < c.counts; counts++ {
+			for last := 0; last < c.lasts; last++ {
+				sum := &c.count[i][c.state(counts, last)]
+				for k := range classes {
+					w := c.choices(k, last)
+					if w == 0 {
+						continue
+					}
+					next := &c.count[i+1][c.state(c.advance(counts, k), c.lastOf(k))]
+					sum.Add(sum, term.Mul(term.SetInt64(int64(w)), next))
+				}
+			}
+		}
+	}
+
+	for i := range c.count {
+		for j := range c.count[i] {
+			// A big.Int is a sign and a slice header, 32 bytes on 64-bit platforms
+			c.size += 32 + len(c.count[i][j].Bits())*bits.UintSize/8
+		}
+	}
+
+	if c.count[0][c.state(0, c.start())].Sign() == 0 {
+		return nil, fmt.Errorf("%w: no password satisfies the policy", ErrInvalidPolicy)
+	}
+	return c, nil
+}
+
+// state returns the table index of capped counts and a last class
+func (c *compiledPolicy) state(counts, last int) int {
+	return counts*c.lasts + last
+}
+
+// start returns the last-class value of the empty prefix
+func (c *compiledPolicy) start() int {
+	return c.lasts - 1
+}
+
+// lastOf returns the last-class value after a character of class k
+func (c *compiledPolicy) lastOf(k int) int {
+	if c.noRepeat {
+		return k
+	}
+	return 0
+}
+
+// choices returns the number of characters of class k that may follow a
+// character of class last
+func (c *compiledPolicy) choices(k, last int) int {
+	n := len(c.classes[k].chars)
+	if c.noRepeat && k == last {
+		n-- // Any but the previous character
+	}
+	return n
+}
+
+// advance returns the capped counts after adding a character of class k
+func (c *compiledPolicy) advance(counts, k int) int {
+	stride := 1
+	for j := len(c.radix) - 1; j > k; j-- {
+		stride *= c.radix[j]
+	}
+	if counts/stride%c.radix[k] < c.radix[k]-1 {
+		counts += stride
+	}
+	return counts
+}
+
+// generate draws one password, uniformly among those counted by the table
+func (c *compiledPolicy) generate(g *Generator) ([]rune, error) {
+	password := make([]rune, 0, c.length)
+	counts, last := 0, c.start()
+	prev := -1 // index of the previous character within its class
+
+	var weight big.Int
+	for i := 0; i < c.length; i++ {
+		r, err := g.bigIntn(&c.count[i][c.state(counts, last)])
+		if err != nil {
+			return nil, err
+		}
+
+		// Choose the class of the next character in proportion to the
+		// number of passwords that continue with it
+		k := 0
+		for ; k < len(c.classes); k++ {
+			w := c.choices(k, last)
+			if w == 0 {
+				continue
+			}
+			next := &c.count[i+1][c.state(c.advance(counts, k), c.lastOf(k))]
+			weight.Mul(weight.SetInt64(int64(w)), next)
+			if r.Cmp(&weight) < 0 {
+				break
+			}
+			r.Sub(r, &weight)
+		}
+
+		// Then the character itself, uniformly among those allowed
+		idx, err := g.uint64n(uint64(c.choices(k, last)))
+		if err != nil {
+			return nil, err
+		}
+		j := int(idx)
+		if c.noRepeat && k == last && j >= prev {
+			j++ // Skip the previous character
+		}
+
+		password = append(password, c.classes[k].chars[j])
+		counts, last, prev = c.advance(counts, k), c.lastOf(k), j
+	}
+	return password, nil
+}
+
+// countWithoutSequences returns the number of passwords that satisfy the
+// policy including NoSequential, which the counting table leaves out.
+//
+// It counts prefixes forward by capped counts, last character and the step
+// (+1, -1 or none) from the character before it. Rather than pairing every
+// last character with every next one, each next character y starts from the
+// total of all prefixes in a state and has the few excluded ones subtracted:
+// those ending in y itself under NoRepeat, and those ending in y-1 or y+1,
+// which move to a stepped state unless they would complete a sequence.
+func (c *compiledPolicy) countWithoutSequences() *big.Int {
+	var chars []rune
+	var classOf []int
+	index := make(map[rune]int)
+	for k, class := range c.classes {
+		for _, r := range class.chars {
+			index[r] = len(chars)
+			chars = append(chars, r)
+			classOf = append(classOf, k)
+		}
+	}
+	neighbour := func(r rune) int {
+		if i, ok := index[r]; ok {
+			return i
+		}
+		return -1
+	}
+
+	// layer[step][counts*len(chars)+x] counts prefixes in capped counts
+	// state counts, ending in chars[x] after a step of 0, +1 or -1
+	const flat, up, down = 0, 1, 2
+	newLayer := func() [3][]big.Int {
+		var l [3][]big.Int
+		for d := range l {
+			l[d] = make([]big.Int, c.counts*len(chars))
+		}
+		return l
+	}
+	cur, next := newLayer(), newLayer()
+
+	for x := range chars {
+		cur[flat][c.advance(0, classOf[x])*len(chars)+x].SetInt64(1)
+	}
+
+	var total big.Int
+	ending := make([]big.Int, len(chars)) // prefixes ending in each character, any step
+	var v big.Int
+	for i := 1; i < c.length; i++ {
+		for d := range next {
+			for j := range next[d] {
+				next[d][j].SetInt64(0)
+			}
+		}
+
+		for counts := 0; counts < c.counts; counts++ {
+			base := counts * len(chars)
+			total.SetInt64(0)
+			for x := range chars {
+				e := &ending[x]
+				e.Add(&cur[flat][base+x], &cur[up][base+x])
+				e.Add(e, &cur[down][base+x])
+				total.Add(&total, e)
+			}
+			if total.Sign() == 0 {
+				continue
+			}
+
+			for y, r := range chars {
+				to := c.advance(counts, classOf[y])*len(chars) + y
+				below, above := neighbour(r-1), neighbour(r+1)
+
+				v.Set(&total)
+				if c.noRepeat {
+					v.Sub(&v, &ending[y])
+				}
+				if below >= 0 {
+					v.Sub(&v, &ending[below])
+					// Stepping up from r-1 is fine unless r-2, r-1 already stepped up
+					stepped := &next[up][to]
+					stepped.Add(stepped, &ending[below])
+					stepped.Sub(stepped, &cur[up][base+below])
+				}
+				if above >= 0 {
+					v.Sub(&v, &ending[above])
+					stepped := &next[down][to]
+					stepped.Add(stepped, &ending[above])
+					stepped.Sub(stepped, &cur[down][base+above])
+				}
+				next[flat][to].Add(&next[flat][to], &v)
+			}
+		}
+		cur, next = next, cur
+	}
+
+	n := new(big.Int)
+	base := (c.counts - 1) * len(chars)
+	for d := range cur {
+		for x := range chars {
+			n.Add(n, &cur[d][base+x])
+		}
+	}
+	return n
+}
+
+// hasSequence reports whether s contains three characters whose code points
+// rise or fall by one, as in "abc" or "321"
+func hasSequence(s []rune) bool {
+	for i := 2; i < len(s); i++ {
+		step := s[i-1] - s[i-2]
+		if (step == 1 || step == -1) && s[i]-s[i-1] == step {
+			return true
+		}
+	}
+	return false
+}
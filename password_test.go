@@ -0,0 +1,268 @@
+package rand
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// excludeAllBut returns the letters and digits not in keep, for policies
+// small enough to enumerate
+func excludeAllBut(keep string) string {
+	var sb strings.Builder
+	for _, r := range NormalLetters {
+		if !strings.ContainsRune(keep, r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// enumerate returns every string of the given length over alphabet that satisfies valid
+func enumerate(alphabet []rune, length int, valid func([]rune) bool) []string {
+	var out []string
+	s := make([]rune, length)
+	var rec func(i int)
+	rec = func(i int) {
+		if i == length {
+			if valid(s) {
+				out = append(out, string(s))
+			}
+			return
+		}
+		for _, r := range alphabet {
+			s[i] = r
+			rec(i + 1)
+		}
+	}
+	rec(0)
+	return out
+}
+
+// classCounts returns the number of uppercase letters, lowercase letters,
+// digits and other characters in s
+func classCounts(s []rune) (upper, lower, digits, symbols int) {
+	for _, r := range s {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		case unicode.IsDigit(r):
+			digits++
+		default:
+			symbols++
+		}
+	}
+	return
+}
+
+// satisfies reports whether s obeys the rules of p, checked directly
+func satisfies(p PasswordPolicy, s []rune) bool {
+	upper, lower, digits, symbols := classCounts(s)
+	if len(s) != p.Length || upper < p.MinUpper || lower < p.MinLower || digits < p.MinDigits || symbols < p.MinSymbols {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if p.NoRepeat && s[i] == s[i-1] {
+			return false
+		}
+	}
+	return !p.NoSequential || !hasSequence(s)
+}
+
+// assertUniformPasswords checks by a chi-squared test that p generates every
+// valid password over alphabet equally often
+func assertUniformPasswords(t *testing.T, p PasswordPolicy, alphabet string, draws int) {
+	t.Helper()
+
+	valid := enumerate([]rune(alphabet), p.Length, func(s []rune) bool { return satisfies(p, s) })
+	require.NotEmpty(t, valid)
+	index := make(map[string]int64, len(valid))
+	for i, s := range valid {
+		index[s] = int64(i)
+	}
+
+	g := NewSeeded(uint64(len(valid)))
+	counts := make(map[int64]int)
+	for i := 0; i < draws; i++ {
+		s, err := p.GenerateWith(g)
+		require.NoError(t, err)
+		i, ok := index[s]
+		require.True(t, ok, "generated %q, which violates the policy", s)
+		counts[i]++
+	}
+
+	n := int64(len(valid))
+	stat, critical := chiSquareGOF(counts, draws, 0, n-1, func(int64) float64 { return 1 / float64(n) })
+	assert.Less(t, stat, critical, "%d valid passwords are not equally likely", n)
+}
+
+// TestPasswordPolicyUniform enumerates small policies and checks that every
+// valid password is equally likely
+func TestPasswordPolicyUniform(t *testing.T) {
+	// Minimums alone: a naive "one of each class, then shuffle" would favour
+	// passwords with exactly one digit
+	assertUniformPasswords(t, PasswordPolicy{
+		Length: 3, MinUpper: 1, MinDigits: 1, Symbols: "!", Exclude: excludeAllBut("ABa12"),
+	}, "ABa12!", 200000)
+
+	// Minimums with NoRepeat
+	assertUniformPasswords(t, PasswordPolicy{
+		Length: 4, MinLower: 2, MinSymbols: 1, Symbols: "#", NoRepeat: true, Exclude: excludeAllBut("abX7"),
+	}, "abX7#", 200000)
+
+	// NoSequential on a run-heavy alphabet, with NoRepeat
+	assertUniformPasswords(t, PasswordPolicy{
+		Length: 4, MinDigits: 1, NoSymbols: true, NoRepeat: true, NoSequential: true, Exclude: excludeAllBut("abc123"),
+	}, "abc123", 300000)
+}
+
+// TestPasswordPolicy checks the rules on realistic policies
+func TestPasswordPolicy(t *testing.T) {
+	policies := map[string]PasswordPolicy{
+		"default": DefaultPasswordPolicy(),
+		"strict": {
+			Length: 24, MinUpper: 3, MinLower: 3, MinDigits: 3, MinSymbols: 3,
+			NoRepeat: true, NoSequential: true, VisibleOnly: true,
+		},
+		"digits only": {Length: 12, MinDigits: 12, NoRepeat: true, NoSequential: true},
+		"exclusions":  {Length: 20, MinSymbols: 2, Symbols: "-_.", Exclude: "aeiouAEIOU"},
+		"no symbols":  {Length: 30, MinUpper: 1, NoSymbols: true},
+	}
+
+	for name, p := range policies {
+		for i := 0; i < 500; i++ {
+			s, err := p.Generate()
+			require.NoError(t, err, name)
+			runes := []rune(s)
+			require.True(t, satisfies(p, runes), "%s: %q violates the policy", name, s)
+
+			_, _, _, symbols := classCounts(runes)
+			if p.NoSymbols {
+				require.Zero(t, symbols, "%s: %q", name, s)
+			}
+			for _, r := range runes {
+				require.False(t, strings.ContainsRune(p.Exclude, r), "%s: %q contains excluded %q", name, s, r)
+				if p.VisibleOnly {
+					require.False(t, strings.ContainsRune("0OIl1", r), "%s: %q contains ambiguous %q", name, s, r)
+				}
+				if p.Symbols != "" && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					require.True(t, strings.ContainsRune(p.Symbols, r), "%s: %q contains foreign symbol %q", name, s, r)
+				}
+			}
+		}
+	}
+
+	// Every allowed character appears
+	seen := make(map[rune]bool)
+	for i := 0; i < 2000; i++ {
+		s, err := DefaultPasswordPolicy().Generate()
+		require.NoError(t, err)
+		for _, r := range s {
+			seen[r] = true
+		}
+	}
+	assert.Len(t, seen, len(NormalLetters)+len(PasswordSymbols))
+}
+
+// TestPasswordPolicyInvalid checks that inconsistent policies are rejected
+func TestPasswordPolicyInvalid(t *testing.T) {
+	for name, p := range map[string]PasswordPolicy{
+		"zero value":        {},
+		"negative minimum":  {Length: 8, MinDigits: -1},
+		"minimums too long": {Length: 4, MinUpper: 2, MinLower: 2, MinDigits: 1},
+		"no symbols left":   {Length: 8, MinSymbols: 1, NoSymbols: true},
+		"excluded class":    {Length: 8, MinDigits: 1, Exclude: "0123456789"},
+		"visible class":     {Length: 4, MinDigits: 1, VisibleOnly: true, Exclude: "23456789"},
+		"letter symbol":     {Length: 8, Symbols: "!a"},
+		"space symbol":      {Length: 8, Symbols: "! "},
+		"nothing left":      {Length: 8, NoSymbols: true, Exclude: NormalLetters},
+		"unsatisfiable":     {Length: 2, NoSymbols: true, NoRepeat: true, Exclude: excludeAllBut("x")},
+		"too large":         {Length: 120, MinUpper: 30, MinLower: 30, MinDigits: 30, MinSymbols: 30},
+		"too long":          {Length: MaxPasswordLength + 1},
+	} {
+		_, err := p.Generate()
+		assert.ErrorIs(t, err, ErrInvalidPolicy, name)
+	}
+
+	// A single allowed character can still make a one-character password
+	s, err := PasswordPolicy{Length: 1, NoSymbols: true, NoRepeat: true, Exclude: excludeAllBut("x")}.Generate()
+	require.NoError(t, err)
+	assert.Equal(t, "x", s)
+}
+
+// TestPasswordPolicyLimits checks the length cap, the limit on the counting
+// table and the size of the cache of counting tables
+func TestPasswordPolicyLimits(t *testing.T) {
+	p := PasswordPolicy{Length: MaxPasswordLength, MinUpper: 4, MinLower: 4, MinDigits: 3, MinSymbols: 3}
+	s, err := p.Generate()
+	require.NoError(t, err)
+	assert.Len(t, s, MaxPasswordLength)
+
+	// Ordinary minimums fit with NoRepeat up to the documented length
+	p4 := PasswordPolicy{Length: 64, MinUpper: 4, MinLower: 4, MinDigits: 4, MinSymbols: 4, NoRepeat: true}
+	for _, length := range []int{64, 91} {
+		p4.Length = length
+		s, err := p4.Generate()
+		require.NoError(t, err, "length %d", length)
+		assert.Len(t, s, length)
+	}
+	p4.Length = 92
+	_, err = p4.Generate()
+	assert.ErrorIs(t, err, ErrInvalidPolicy)
+	p4.NoRepeat = false
+	_, err = p4.Generate()
+	assert.NoError(t, err)
+	p4.Length = MaxPasswordLength
+	_, err = p4.Generate()
+	assert.NoError(t, err)
+
+	// Distinct long policies never hold more than the budget between them
+	for i := 0; i < maxCompiledPolicies+16; i++ {
+		p.MinSymbols = i % 4
+		p.Length = MaxPasswordLength - i
+		_, err := p.Entropy()
+		require.NoError(t, err)
+
+		compiledPoliciesMu.Lock()
+		total := 0
+		for _, c := range compiledPolicies {
+			total += c.size
+		}
+		assert.Equal(t, total, compiledPoliciesBytes)
+		assert.LessOrEqual(t, compiledPoliciesBytes, maxCompiledBytes)
+		compiledPoliciesMu.Unlock()
+	}
+}
+
+// TestPasswordPolicyGenerator verifies seeded reproducibility and strict-mode errors
+func TestPasswordPolicyGenerator(t *testing.T) {
+	p := DefaultPasswordPolicy()
+	p.NoRepeat = true
+
+	a, b := NewSeeded(13), NewSeeded(13)
+	for i := 0; i < 20; i++ {
+		x, err := p.GenerateWith(a)
+		require.NoError(t, err)
+		y, err := p.GenerateWith(b)
+		require.NoError(t, err)
+		assert.Equal(t, x, y)
+	}
+
+	strict := NewGenerator(failingSource{}, WithStrict())
+	_, err := p.GenerateWith(strict)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+}
+
+func BenchmarkPasswordPolicy(b *testing.B) {
+	p := DefaultPasswordPolicy()
+	p.NoRepeat = true
+	p.NoSequential = true
+	for i := 0; i < b.N; i++ {
+		_, _ = p.Generate()
+	}
+}
@@ -0,0 +1,119 @@
+package rand
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// PCG is a deterministic Source implementing the 128-bit PCG-DXSM generator.
+//
+// Its output depends only on the seed: it is identical across Go versions,
+// platforms and architectures, and matches math/rand/v2's PCG for the same
+// seed pair. PCG is NOT cryptographically secure; use it only where
+// reproducibility matters more than unpredictability, such as test fixtures.
+//
+// A PCG is safe for concurrent use, although concurrent readers observe the
+// stream in a nondeterministic order.
+type PCG struct {
+	mu     sync.Mutex
+	hi, lo uint64
+
+	// Unread bytes of the last Uint64 handed out by Read, lowest byte first
+	buf    uint64
+	bufLen int
+}
+
+// NewPCG returns a PCG source seeded with the given 128-bit state.
+//
+// Example:
+//
+//	src := rand.NewPCG(1, 2)
+//	g := rand.NewGenerator(src)
+func NewPCG(seed1, seed2 uint64) *PCG {
+	return &PCG{hi: seed1, lo: seed2}
+}
+
+// NewSeeded returns a deterministic Generator backed by a PCG source.
+// The same seed yields byte-identical results from every Generator method,
+// which makes failures in randomized tests reproducible.
+//
+// The returned Generator is NOT suitable for secrets.
+//
+// Example:
+//
+//	g := rand.NewSeeded(42)
+//	fixture := g.String(16) // Same value on every run
+func NewSeeded(seed uint64) *Generator {
+	return NewGenerator(NewPCG(seed, 0))
+}
+
+// Seed resets the PCG to the state NewPCG(seed1, seed2) would return.
+func (p *PCG) Seed(seed1, seed2 uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.hi, p.lo = seed1, seed2
+	p.bufLen = 0
+}
+
+// next advances the 128-bit LCG state and returns it
+func (p *PCG) next() (hi, lo uint64) {
+	const (
+		mulHi = 2549297995355413924
+		mulLo = 4865540595714422341
+		incHi = 6364136223846793005
+		incLo = 1442695040888963407
+	)
+
+	// state = state * mul + inc
+	hi, lo = bits.Mul64(p.lo, mulLo)
+	hi += p.hi*mulLo + p.lo*mulHi
+	lo, c := bits.Add64(lo, incLo, 0)
+	hi, _ = bits.Add64(hi, incHi, c)
+	p.lo = lo
+	p.hi = hi
+	return hi, lo
+}
+
+// uint64 returns the next output using the DXSM (double xorshift multiply) permutation
+func (p *PCG) uint64() uint64 {
+	hi, lo := p.next()
+
+	const cheapMul = 0xda942042e4dd58b5
+	hi ^= hi >> 32
+	hi *= cheapMul
+	hi ^= hi >> 48
+	hi *= (lo | 1)
+	return hi
+}
+
+// Uint64 returns the next 64 random bits of the stream.
+// It discards any bytes left over from a previous partial Read.
+func (p *PCG) Uint64() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.bufLen = 0
+	return p.uint64()
+}
+
+// Read fills b with the little-endian bytes of successive outputs. It never fails.
+// Bytes left over from one call are returned first by the next, so the byte
+// stream does not depend on how reads are split.
+func (p *PCG) Read(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	n := len(b)
+	for len(b) > 0 {
+		if p.bufLen == 0 {
+			p.buf = p.uint64()
+			p.bufLen = 8
+		}
+		b[0] = byte(p.buf)
+		p.buf >>= 8
+		p.bufLen--
+		b = b[1:]
+	}
+	return n, nil
+}
@@ -0,0 +1,92 @@
+package rand
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPCGGolden pins the PCG output so seeded results stay stable across Go versions
+func TestPCGGolden(t *testing.T) {
+	// Same values as math/rand/v2's NewPCG(1, 2)
+	expected := []uint64{
+		0xc4f5a58656eef510,
+		0x9dcec3ad077dec6c,
+		0xc8d04605312f8088,
+		0xcbedc0dcb63ac19a,
+	}
+
+	p := NewPCG(1, 2)
+	for i, want := range expected {
+		assert.Equal(t, want, p.Uint64(), "PCG output %d changed", i)
+	}
+}
+
+// TestPCGRead validates that the byte stream does not depend on how reads are split
+func TestPCGRead(t *testing.T) {
+	whole := make([]byte, 64)
+	n, err := NewPCG(7, 8).Read(whole)
+	require.NoError(t, err)
+	assert.Equal(t, 64, n)
+
+	// Bytes are the little-endian encoding of successive outputs
+	p := NewPCG(7, 8)
+	for i := 0; i < 8; i++ {
+		assert.Equal(t, p.Uint64(), binary.LittleEndian.Uint64(whole[i*8:]))
+	}
+
+	split := make([]byte, 64)
+	p = NewPCG(7, 8)
+	offset := 0
+	for _, size := range []int{3, 1, 9, 16, 5, 30} {
+		_, err := p.Read(split[offset : offset+size])
+		require.NoError(t, err)
+		offset += size
+	}
+	assert.Equal(t, whole, split)
+}
+
+// TestPCGSeed validates that Seed restarts the stream
+func TestPCGSeed(t *testing.T) {
+	p := NewPCG(1, 2)
+	first := p.Uint64()
+	_, _ = p.Read(make([]byte, 3))
+
+	p.Seed(1, 2)
+	assert.Equal(t, first, p.Uint64())
+
+	p.Seed(3, 4)
+	assert.Equal(t, NewPCG(3, 4).Uint64(), p.Uint64())
+}
+
+// TestNewSeeded validates that the same seed reproduces every API
+func TestNewSeeded(t *testing.T) {
+	draw := func(g *Generator) []interface{} {
+		return []interface{}{
+			g.Int32(), g.Int64(), g.Uint32(), g.Uint64(), g.Int(), g.Uint(),
+			g.RangeInt(-50, 50), g.RangeInt64(0, 1<<40), g.RangeUint32(5, 10), g.RangeUint64(0, 3),
+			g.String(16), g.VisibleString(16), g.CustomString("天地玄黄宇宙洪荒", 8),
+			g.AlphaString(8), g.NumericString(8), g.LowercaseString(8), g.UppercaseString(8),
+			g.UUID(),
+		}
+	}
+
+	a := draw(NewSeeded(42))
+	b := draw(NewSeeded(42))
+	assert.Equal(t, a, b, "the same seed should reproduce identical output")
+
+	c := draw(NewSeeded(43))
+	assert.NotEqual(t, a, c, "different seeds should produce different output")
+}
+
+// TestSeededGolden pins seeded output of the high-level APIs so fixtures stay reproducible
+func TestSeededGolden(t *testing.T) {
+	g := NewSeeded(42)
+
+	assert.Equal(t, []int{85, 96, 13}, []int{g.RangeInt(0, 100), g.RangeInt(0, 100), g.RangeInt(0, 100)})
+	assert.Equal(t, "6bXVmEXxBefGAvdu", g.String(16))
+	assert.Equal(t, "宙洪玄宇宙洪玄黄", g.CustomString("天地玄黄宇宙洪荒", 8))
+	assert.Equal(t, "92a3f383-b66d-499e-b463-6058bac58455", g.UUID())
+}
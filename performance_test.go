@@ -1,7 +1,9 @@
 package rand
 
 import (
+	cRand "crypto/rand"
 	"fmt"
+	"math/big"
 	"testing"
 	"time"
 )
@@ -61,7 +63,7 @@ func TestMemoryUsage(t *testing.T) {
 		}
 	})
 
-	// Test range functions - should have minimal allocations due to object pooling
+	// Test range functions - should not allocate at all
 	t.Run("RangeMemoryUsage", func(t *testing.T) {
 		for i := 0; i < samples; i++ {
 			_ = RangeInt(0, 1000)
@@ -129,3 +131,84 @@ func BenchmarkStringLengths(b *testing.B) {
 		})
 	}
 }
+
+// TestZeroAllocations validates that integer and range generation never allocates
+func TestZeroAllocations(t *testing.T) {
+	funcs := map[string]func(){
+		"Int32":       func() { _ = Int32() },
+		"Int64":       func() { _ = Int64() },
+		"Uint64":      func() { _ = Uint64() },
+		"Int":         func() { _ = Int() },
+		"RangeInt":    func() { _ = RangeInt(0, 1000) },
+		"RangeInt64":  func() { _ = RangeInt64(-1000, 1000) },
+		"RangeUint32": func() { _ = RangeUint32(10, 50) },
+		"RangeUint64": func() { _ = RangeUint64(0, 1<<63+1) },
+	}
+
+	for name, fn := range funcs {
+		if allocs := testing.AllocsPerRun(1000, fn); allocs != 0 {
+			t.Errorf("%s allocated %.1f times per call, expected 0", name, allocs)
+		}
+	}
+}
+
+// BenchmarkBoundedSampling compares Lemire's multiply-shift sampler with crypto/rand.Int
+func BenchmarkBoundedSampling(b *testing.B) {
+	bounds := []uint64{6, 1000, 1<<63 + 1}
+
+	for _, bound := range bounds {
+		b.Run(fmt.Sprintf("Lemire-%d", bound), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = defaultGenerator.uint64n(bound)
+			}
+		})
+
+		b.Run(fmt.Sprintf("BigInt-%d", bound), func(b *testing.B) {
+			max := new(big.Int).SetUint64(bound)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = cRand.Int(cRand.Reader, max)
+			}
+		})
+	}
+}
+
+// BenchmarkIntegerAllocations reports per-call allocations of the integer and range functions
+func BenchmarkIntegerAllocations(b *testing.B) {
+	b.Run("Int64", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = Int64()
+		}
+	})
+
+	b.Run("RangeInt", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = RangeInt(0, 1000)
+		}
+	})
+
+	b.Run("RangeInt64", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = RangeInt64(1000, 9999)
+		}
+	})
+
+	b.Run("RangeUint64", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = RangeUint64(1000, 9999)
+		}
+	})
+
+	b.Run("SeededRangeInt", func(b *testing.B) {
+		g := NewSeeded(1)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = g.RangeInt(0, 1000)
+		}
+	})
+}
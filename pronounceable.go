@@ -0,0 +1,219 @@
+package rand
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PronounceableSymbols is the default symbol set of a PronounceablePolicy:
+// symbols with short, unambiguous spoken names
+const PronounceableSymbols = "!#$%*+-=?@"
+
+// The letters and digits of pronounceable passwords. They share the
+// exclusions of VisibleLetters, so there is no i, l or o to mistake for 1 or 0.
+// y counts as a vowel and q, which wants a u after it, is left out.
+var (
+	pronounceableConsonants = visibleOnly("bcdfghjklmnprstvwxz")
+	pronounceableVowels     = visibleOnly("aeiouy")
+	pronounceableDigits     = visibleOnly("0123456789")
+)
+
+// visibleOnly returns the characters of set that are not easily confused:
+// letters and digits outside VisibleLetters are removed, anything else kept
+func visibleOnly(set string) string {
+	var sb strings.Builder
+	for _, r := range set {
+		if isVisible(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// PronounceablePolicy describes passwords that are easy to read out: lowercase
+// letters alternating between consonants and vowels, as in "kabetuxa",
+// followed by digits and symbols in random order.
+//
+// Pronounceable passwords carry less entropy per character than
+// VisibleString, about 3.1 bits instead of 5.8, so they need to be longer for
+// the same strength; Entropy reports the exact figure.
+//
+// Example:
+//
+//	policy := rand.PronounceablePolicy{Length: 10, Digits: 2, Symbols: 1}
+//	password, err := policy.Generate() // Like "gusenabuwe4=7"
+type PronounceablePolicy struct {
+	// Length is the number of letters, which must be at least 1. The letters
+	// start with a consonant.
+	Length int
+
+	// Digits is the number of digits, drawn from 2-9
+	Digits int
+
+	// Symbols is the number of symbols, drawn from SymbolSet
+	Symbols int
+
+	// SymbolSet replaces PronounceableSymbols when not empty. It must not
+	// contain letters, digits or whitespace.
+	SymbolSet string
+}
+
+// DefaultPronounceablePolicy returns a policy for ten letters followed by two
+// digits, carrying 36.8 bits of entropy: enough for a temporary password that
+// must be changed on first use.
+//
+// Example:
+//
+//	policy := rand.DefaultPronounceablePolicy()
+//	policy.Length = 14
+//	password, err := policy.Generate()
+func DefaultPronounceablePolicy() PronounceablePolicy {
+	return PronounceablePolicy{Length: 10, Digits: 2}
+}
+
+// Generate returns a cryptographically secure pronounceable password
+// following the policy. Every letter, digit and symbol is drawn uniformly
+// from its set, and the digits and symbols are arranged uniformly.
+//
+// It returns an error wrapping ErrInvalidPolicy if the policy is inconsistent,
+// and an error wrapping ErrEntropyUnavailable if the entropy source fails in
+// strict mode (see SetStrict).
+func (p PronounceablePolicy) Generate() (string, error) {
+	return p.GenerateWith(defaultGenerator)
+}
+
+// GenerateWith is like Generate but draws from g.
+func (p PronounceablePolicy) GenerateWith(g *Generator) (string, error) {
+	symbols, err := p.symbols()
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.Grow(p.Length + p.Digits + p.Symbols*utf8.UTFMax)
+	for i := 0; i < p.Length; i++ {
+		set := pronounceableConsonants
+		if i%2 == 1 {
+			set = pronounceableVowels
+		}
+		idx, err := g.uint64n(uint64(len(set)))
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(set[idx])
+	}
+
+	suffix := make([]rune, 0, p.Digits+p.Symbols)
+	for i := 0; i < p.Digits; i++ {
+		idx, err := g.uint64n(uint64(len(pronounceableDigits)))
+		if err != nil {
+			return "", err
+		}
+		suffix = append(suffix, rune(pronounceableDigits[idx]))
+	}
+	for i := 0; i < p.Symbols; i++ {
+		idx, err := g.uint64n(uint64(len(symbols)))
+		if err != nil {
+			return "", err
+		}
+		suffix = append(suffix, symbols[idx])
+	}
+	// Shuffling independent draws arranges digits and symbols uniformly
+	if err := ShuffleEWith(g, suffix); err != nil {
+		return "", err
+	}
+
+	sb.WriteString(string(suffix))
+	return sb.String(), nil
+}
+
+// Entropy returns the entropy in bits of the passwords generated by the
+// policy: log2 of the consonants and vowels for every letter, of the digits
+// and symbols for each of those, and of the ways to arrange the digits among
+// the symbols.
+//
+// It returns an error wrapping ErrInvalidPolicy if the policy is inconsistent.
+//
+// Example:
+//
+//	bits, err := rand.DefaultPronounceablePolicy().Entropy() // 36.8 bits
+func (p PronounceablePolicy) Entropy() (float64, error) {
+	symbols, err := p.symbols()
+	if err != nil {
+		return 0, err
+	}
+
+	consonants, vowels := (p.Length+1)/2, p.Length/2
+	bits := float64(consonants)*math.Log2(float64(len(pronounceableConsonants))) +
+		float64(vowels)*math.Log2(float64(len(pronounceableVowels))) +
+		float64(p.Digits)*math.Log2(float64(len(pronounceableDigits))) +
+		float64(p.Symbols)*math.Log2(float64(len(symbols)))
+
+	// log2 of the binomial coefficient placing the digits among the symbols
+	a, _ := math.Lgamma(float64(p.Digits + p.Symbols + 1))
+	b, _ := math.Lgamma(float64(p.Digits + 1))
+	c, _ := math.Lgamma(float64(p.Symbols + 1))
+	return bits + (a-b-c)/math.Ln2, nil
+}
+
+// symbols validates the policy and returns its distinct symbols
+func (p PronounceablePolicy) symbols() ([]rune, error) {
+	if p.Length < 1 {
+		return nil, fmt.Errorf("%w: length is %d, must be at least 1", ErrInvalidPolicy, p.Length)
+	}
+	if p.Digits < 0 || p.Symbols < 0 {
+		return nil, fmt.Errorf("%w: negative number of digits or symbols", ErrInvalidPolicy)
+	}
+
+	set := PronounceableSymbols
+	if p.SymbolSet != "" {
+		set = p.SymbolSet
+	}
+	var symbols []rune
+	seen := make(map[rune]bool)
+	for _, r := range set {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) || r == utf8.RuneError {
+			return nil, fmt.Errorf("%w: SymbolSet contains %q", ErrInvalidPolicy, r)
+		}
+		if !seen[r] {
+			seen[r] = true
+			symbols = append(symbols, r)
+		}
+	}
+	return symbols, nil
+}
+
+// Pronounceable generates a cryptographically secure password of the given
+// number of lowercase letters, alternating between consonants and vowels so
+// that it can be read out, such as "kabetuxa". It carries about 3.1 bits per
+// letter (see PronounceablePolicy.Entropy) and returns an empty string if
+// length <= 0.
+//
+// Example:
+//
+//	password := rand.Pronounceable(12) // Like "befumazekuna"
+func Pronounceable(length int) string {
+	return defaultGenerator.Pronounceable(length)
+}
+
+// Pronounceable is like the package-level Pronounceable but draws from g.
+func (g *Generator) Pronounceable(length int) string {
+	return must(g.PronounceableE(length))
+}
+
+// PronounceableE is like Pronounceable but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func PronounceableE(length int) (string, error) {
+	return defaultGenerator.PronounceableE(length)
+}
+
+// PronounceableE is like the package-level PronounceableE but draws from g.
+func (g *Generator) PronounceableE(length int) (string, error) {
+	if length <= 0 {
+		return "", nil
+	}
+	return PronounceablePolicy{Length: length}.GenerateWith(g)
+}
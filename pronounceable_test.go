@@ -0,0 +1,156 @@
+package rand
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPronounceableSets checks that the letter sets honour VisibleLetters
+func TestPronounceableSets(t *testing.T) {
+	assert.Equal(t, "bcdfghjkmnprstvwxz", pronounceableConsonants)
+	assert.Equal(t, "aeuy", pronounceableVowels)
+	assert.Equal(t, "23456789", pronounceableDigits)
+	for _, r := range pronounceableConsonants + pronounceableVowels + pronounceableDigits {
+		assert.Contains(t, VisibleLetters, string(r))
+	}
+	assert.Equal(t, "!-", visibleOnly("!0O-Il1"))
+}
+
+// TestPronounceablePolicy checks the shape of generated passwords
+func TestPronounceablePolicy(t *testing.T) {
+	for name, p := range map[string]PronounceablePolicy{
+		"default":     DefaultPronounceablePolicy(),
+		"letters":     {Length: 7},
+		"symbols":     {Length: 6, Symbols: 3},
+		"mixed":       {Length: 9, Digits: 2, Symbols: 2},
+		"custom set":  {Length: 4, Symbols: 4, SymbolSet: "€.."},
+		"single char": {Length: 1},
+	} {
+		symbols, err := p.symbols()
+		require.NoError(t, err, name)
+		for i := 0; i < 300; i++ {
+			s, err := p.Generate()
+			require.NoError(t, err, name)
+			runes := []rune(s)
+			require.Len(t, runes, p.Length+p.Digits+p.Symbols, "%s: %q", name, s)
+
+			for j, r := range runes[:p.Length] {
+				set := pronounceableConsonants
+				if j%2 == 1 {
+					set = pronounceableVowels
+				}
+				require.Contains(t, set, string(r), "%s: %q", name, s)
+			}
+			digits := 0
+			for _, r := range runes[p.Length:] {
+				if strings.ContainsRune(pronounceableDigits, r) {
+					digits++
+				} else {
+					require.Contains(t, string(symbols), string(r), "%s: %q", name, s)
+				}
+			}
+			require.Equal(t, p.Digits, digits, "%s: %q", name, s)
+		}
+	}
+}
+
+// TestPronounceablePolicyUniform enumerates a small policy and checks that
+// every password is equally likely and that Entropy counts them exactly
+func TestPronounceablePolicyUniform(t *testing.T) {
+	p := PronounceablePolicy{Length: 2, Digits: 1, Symbols: 2, SymbolSet: "!?"}
+
+	var all []string
+	for _, c := range pronounceableConsonants {
+		for _, v := range pronounceableVowels {
+			for _, d := range pronounceableDigits {
+				for _, s := range []string{"!!", "!?", "?!", "??"} {
+					for k := 0; k <= 2; k++ {
+						all = append(all, string([]rune{c, v})+s[:k]+string(d)+s[k:])
+					}
+				}
+			}
+		}
+	}
+	index := make(map[string]int64, len(all))
+	for i, s := range all {
+		index[s] = int64(i)
+	}
+	require.Len(t, index, 18*4*8*4*3)
+
+	bits, err := p.Entropy()
+	require.NoError(t, err)
+	assert.InDelta(t, math.Log2(float64(len(all))), bits, 1e-9)
+
+	g := NewSeeded(24)
+	const draws = 200000
+	counts := make(map[int64]int)
+	for i := 0; i < draws; i++ {
+		s, err := p.GenerateWith(g)
+		require.NoError(t, err)
+		k, ok := index[s]
+		require.True(t, ok, "unexpected password %q", s)
+		counts[k]++
+	}
+	n := int64(len(all))
+	stat, critical := chiSquareGOF(counts, draws, 0, n-1, func(int64) float64 { return 1 / float64(n) })
+	assert.Less(t, stat, critical)
+}
+
+// TestPronounceableEntropy checks the reported entropies against VisibleString
+func TestPronounceableEntropy(t *testing.T) {
+	bits, err := DefaultPronounceablePolicy().Entropy()
+	require.NoError(t, err)
+	assert.InDelta(t, 36.85, bits, 0.01)
+
+	bits, err = PronounceablePolicy{Length: 7}.Entropy()
+	require.NoError(t, err)
+	assert.InDelta(t, 4*math.Log2(18)+3*math.Log2(4), bits, 1e-9)
+	assert.Less(t, bits, Entropy(VisibleLetters, 7))
+
+	// Duplicate symbols count once
+	bits, err = PronounceablePolicy{Length: 2, Symbols: 1, SymbolSet: "!!?"}.Entropy()
+	require.NoError(t, err)
+	assert.InDelta(t, math.Log2(18*4*2), bits, 1e-9)
+}
+
+// TestPronounceablePolicyInvalid checks that inconsistent policies are rejected
+func TestPronounceablePolicyInvalid(t *testing.T) {
+	for name, p := range map[string]PronounceablePolicy{
+		"zero value":       {},
+		"negative digits":  {Length: 4, Digits: -1},
+		"negative symbols": {Length: 4, Symbols: -2},
+		"letter symbol":    {Length: 4, Symbols: 1, SymbolSet: "!a"},
+		"digit symbol":     {Length: 4, Symbols: 1, SymbolSet: "!7"},
+		"space symbol":     {Length: 4, Symbols: 1, SymbolSet: "! "},
+	} {
+		_, err := p.Generate()
+		assert.ErrorIs(t, err, ErrInvalidPolicy, name)
+		_, err = p.Entropy()
+		assert.ErrorIs(t, err, ErrInvalidPolicy, name)
+	}
+}
+
+// TestPronounceable verifies the convenience functions, seeding and strict mode
+func TestPronounceable(t *testing.T) {
+	assert.Len(t, Pronounceable(12), 12)
+	assert.Empty(t, Pronounceable(0))
+	assert.Empty(t, Pronounceable(-4))
+	assert.Equal(t, NewSeeded(25).Pronounceable(10), NewSeeded(25).Pronounceable(10))
+
+	strict := NewGenerator(failingSource{}, WithStrict())
+	_, err := strict.PronounceableE(8)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = DefaultPronounceablePolicy().GenerateWith(strict)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+}
+
+func BenchmarkPronounceable(b *testing.B) {
+	p := PronounceablePolicy{Length: 12, Digits: 2, Symbols: 1}
+	for i := 0; i < b.N; i++ {
+		_, _ = p.Generate()
+	}
+}
@@ -2,6 +2,7 @@ package rand
 
 import (
 	"errors"
+	"math/big"
 )
 
 // ErrInvalidRange is returned when the range parameters are invalid
@@ -11,7 +12,8 @@ var ErrInvalidRange = errors.New("invalid range: min must be less than max")
 // It returns an error if min >= max.
 //
 // The function uses crypto/rand for secure random generation with fallback to math/rand.
-// The distribution is uniform across the specified range.
+// The distribution is uniform across the specified range. Spans wider than math.MaxInt,
+// such as [math.MinInt, math.MaxInt), are supported without overflow.
 //
 // Parameters:
 //   - min: the minimum value (inclusive)
@@ -19,7 +21,7 @@ var ErrInvalidRange = errors.New("invalid range: min must be less than max")
 //
 // Returns:
 //   - A random integer in [min, max)
-//   - An error if the range is invalid
+//   - An error if the range is invalid, or if the entropy source fails in strict mode
 //
 // Example:
 //
@@ -29,32 +31,20 @@ var ErrInvalidRange = errors.New("invalid range: min must be less than max")
 //	}
 //	// n is between 10 and 99 (inclusive)
 func RangeIntSafe(min, max int) (int, error) {
-	if min > max {
-		return 0, ErrInvalidRange
-	}
-
-	if min == max {
-		return min, nil
-	}
-
-	rangeSize := max - min
-	maxBig := getBigInt()
-	defer putBigInt(maxBig)
-
-	maxBig.SetInt64(int64(rangeSize))
-	if result, ok := secureRandomBigInt(maxBig); ok {
-		return int(result.Int64()) + min, nil
-	}
+	return defaultGenerator.RangeIntSafe(min, max)
+}
 
-	// Fallback to pseudo-random generation
-	return getFallbackRand().Intn(rangeSize) + min, nil
+// RangeIntSafe is like the package-level RangeIntSafe but draws from g.
+func (g *Generator) RangeIntSafe(min, max int) (int, error) {
+	return rangeSafe(g, min, max)
 }
 
 // RangeInt returns a cryptographically secure random integer in the range [min, max).
 // It returns 0 if min >= max for backward compatibility.
 //
 // Note: This function maintains backward compatibility with the original API.
-// For better error handling, consider using RangeIntSafe instead.
+// For better error handling, consider using RangeIntSafe instead. In strict mode
+// (see SetStrict) it panics if the entropy source fails.
 //
 // Parameters:
 //   - min: the minimum value (inclusive)
@@ -67,18 +57,24 @@ func RangeIntSafe(min, max int) (int, error) {
 //
 //	n := rand.RangeInt(10, 100) // Returns a value between 10 and 99
 func RangeInt(min, max int) int {
-	result, err := RangeIntSafe(min, max)
-	if err != nil {
+	return defaultGenerator.RangeInt(min, max)
+}
+
+// RangeInt is like the package-level RangeInt but draws from g.
+func (g *Generator) RangeInt(min, max int) int {
+	result, err := g.RangeIntSafe(min, max)
+	if errors.Is(err, ErrInvalidRange) {
 		return 0
 	}
-	return result
+	return must(result, err)
 }
 
 // RangeInt64Safe returns a cryptographically secure random int64 in the range [min, max).
 // It returns an error if min >= max.
 //
 // The function uses crypto/rand for secure random generation with fallback to math/rand.
-// The distribution is uniform across the specified range.
+// The distribution is uniform across the specified range. Spans wider than math.MaxInt64,
+// such as [math.MinInt64, math.MaxInt64), are supported without overflow.
 //
 // Parameters:
 //   - min: the minimum value (inclusive)
@@ -86,7 +82,7 @@ func RangeInt(min, max int) int {
 //
 // Returns:
 //   - A random int64 in [min, max)
-//   - An error if the range is invalid
+//   - An error if the range is invalid, or if the entropy source fails in strict mode
 //
 // Example:
 //
@@ -96,32 +92,20 @@ func RangeInt(min, max int) int {
 //	}
 //	// n is between 1000 and 9998 (inclusive)
 func RangeInt64Safe(min, max int64) (int64, error) {
-	if min > max {
-		return 0, ErrInvalidRange
-	}
-
-	if min == max {
-		return min, nil
-	}
-
-	rangeSize := max - min
-	maxBig := getBigInt()
-	defer putBigInt(maxBig)
-
-	maxBig.SetInt64(rangeSize)
-	if result, ok := secureRandomBigInt(maxBig); ok {
-		return result.Int64() + min, nil
-	}
+	return defaultGenerator.RangeInt64Safe(min, max)
+}
 
-	// Fallback to pseudo-random generation
-	return getFallbackRand().Int63n(rangeSize) + min, nil
+// RangeInt64Safe is like the package-level RangeInt64Safe but draws from g.
+func (g *Generator) RangeInt64Safe(min, max int64) (int64, error) {
+	return rangeSafe(g, min, max)
 }
 
 // RangeInt64 returns a cryptographically secure random int64 in the range [min, max).
 // It returns 0 if min >= max for backward compatibility.
 //
 // Note: This function maintains backward compatibility with the original API.
-// For better error handling, consider using RangeInt64Safe instead.
+// For better error handling, consider using RangeInt64Safe instead. In strict mode
+// (see SetStrict) it panics if the entropy source fails.
 //
 // Parameters:
 //   - min: the minimum value (inclusive)
@@ -134,15 +118,48 @@ func RangeInt64Safe(min, max int64) (int64, error) {
 //
 //	n := rand.RangeInt64(1000, 9999) // Returns a value between 1000 and 9998
 func RangeInt64(min, max int64) int64 {
-	result, err := RangeInt64Safe(min, max)
-	if err != nil {
+	return defaultGenerator.RangeInt64(min, max)
+}
+
+// RangeInt64 is like the package-level RangeInt64 but draws from g.
+func (g *Generator) RangeInt64(min, max int64) int64 {
+	result, err := g.RangeInt64Safe(min, max)
+	if errors.Is(err, ErrInvalidRange) {
 		return 0
 	}
-	return result
+	return must(result, err)
+}
+
+// RangeUint32Safe returns a cryptographically secure random uint32 in the range [min, max).
+// It returns an error if min > max.
+//
+// Parameters:
+//   - min: the minimum value (inclusive)
+//   - max: the maximum value (exclusive)
+//
+// Returns:
+//   - A random uint32 in [min, max)
+//   - An error if the range is invalid, or if the entropy source fails in strict mode
+//
+// Example:
+//
+//	n, err := rand.RangeUint32Safe(100, 1000)
+//	if err != nil {
+//		// Handle error
+//	}
+//	// n is between 100 and 999 (inclusive)
+func RangeUint32Safe(min, max uint32) (uint32, error) {
+	return defaultGenerator.RangeUint32Safe(min, max)
+}
+
+// RangeUint32Safe is like the package-level RangeUint32Safe but draws from g.
+func (g *Generator) RangeUint32Safe(min, max uint32) (uint32, error) {
+	return rangeSafe(g, min, max)
 }
 
 // RangeUint32 returns a cryptographically secure random uint32 in the range [min, max).
-// It returns 0 if min >= max.
+// It returns 0 if min >= max. In strict mode (see SetStrict) it panics if the
+// entropy source fails.
 //
 // Parameters:
 //   - min: the minimum value (inclusive)
@@ -155,29 +172,48 @@ func RangeInt64(min, max int64) int64 {
 //
 //	n := rand.RangeUint32(100, 1000) // Returns a value between 100 and 999
 func RangeUint32(min, max uint32) uint32 {
-	if min > max {
-		return 0
-	}
+	return defaultGenerator.RangeUint32(min, max)
+}
 
-	if min == max {
-		return min
+// RangeUint32 is like the package-level RangeUint32 but draws from g.
+func (g *Generator) RangeUint32(min, max uint32) uint32 {
+	result, err := g.RangeUint32Safe(min, max)
+	if errors.Is(err, ErrInvalidRange) {
+		return 0
 	}
+	return must(result, err)
+}
 
-	rangeSize := max - min
-	maxBig := getBigInt()
-	defer putBigInt(maxBig)
-
-	maxBig.SetUint64(uint64(rangeSize))
-	if result, ok := secureRandomBigInt(maxBig); ok {
-		return uint32(result.Uint64()) + min
-	}
+// RangeUint64Safe returns a cryptographically secure random uint64 in the range [min, max).
+// It returns an error if min > max.
+//
+// Parameters:
+//   - min: the minimum value (inclusive)
+//   - max: the maximum value (exclusive)
+//
+// Returns:
+//   - A random uint64 in [min, max)
+//   - An error if the range is invalid, or if the entropy source fails in strict mode
+//
+// Example:
+//
+//	n, err := rand.RangeUint64Safe(1000, 9999)
+//	if err != nil {
+//		// Handle error
+//	}
+//	// n is between 1000 and 9998 (inclusive)
+func RangeUint64Safe(min, max uint64) (uint64, error) {
+	return defaultGenerator.RangeUint64Safe(min, max)
+}
 
-	// Fallback to pseudo-random generation
-	return uint32(getFallbackRand().Uint32())%rangeSize + min
+// RangeUint64Safe is like the package-level RangeUint64Safe but draws from g.
+func (g *Generator) RangeUint64Safe(min, max uint64) (uint64, error) {
+	return rangeSafe(g, min, max)
 }
 
 // RangeUint64 returns a cryptographically secure random uint64 in the range [min, max).
-// It returns 0 if min >= max.
+// It returns 0 if min >= max. In strict mode (see SetStrict) it panics if the
+// entropy source fails.
 //
 // Parameters:
 //   - min: the minimum value (inclusive)
@@ -190,23 +226,320 @@ func RangeUint32(min, max uint32) uint32 {
 //
 //	n := rand.RangeUint64(1000, 9999) // Returns a value between 1000 and 9998
 func RangeUint64(min, max uint64) uint64 {
-	if min > max {
+	return defaultGenerator.RangeUint64(min, max)
+}
+
+// RangeUint64 is like the package-level RangeUint64 but draws from g.
+func (g *Generator) RangeUint64(min, max uint64) uint64 {
+	result, err := g.RangeUint64Safe(min, max)
+	if errors.Is(err, ErrInvalidRange) {
 		return 0
 	}
+	return must(result, err)
+}
+
+// RangeIntClosed returns a cryptographically secure random int in the closed range [min, max].
+// It returns an error if min > max. Unlike the half-open variants, max itself can be
+// returned, so the full range of int can be sampled.
+//
+// Parameters:
+//   - min: the minimum value (inclusive)
+//   - max: the maximum value (inclusive)
+//
+// Returns:
+//   - A random int in [min, max]
+//   - An error if the range is invalid, or if the entropy source fails in strict mode
+//
+// Example:
+//
+//	n, err := rand.RangeIntClosed(1, 6)
+//	if err != nil {
+//		// Handle error
+//	}
+//	// n is between 1 and 6 (inclusive)
+func RangeIntClosed(min, max int) (int, error) {
+	return defaultGenerator.RangeIntClosed(min, max)
+}
+
+// RangeIntClosed is like the package-level RangeIntClosed but draws from g.
+func (g *Generator) RangeIntClosed(min, max int) (int, error) {
+	return rangeClosed(g, min, max)
+}
+
+// RangeInt64Closed returns a cryptographically secure random int64 in the closed range [min, max].
+// It returns an error if min > max. Unlike the half-open variants, max itself can be
+// returned, so the full range of int64 can be sampled.
+//
+// Parameters:
+//   - min: the minimum value (inclusive)
+//   - max: the maximum value (inclusive)
+//
+// Returns:
+//   - A random int64 in [min, max]
+//   - An error if the range is invalid, or if the entropy source fails in strict mode
+//
+// Example:
+//
+//	n, err := rand.RangeInt64Closed(1000, 9999)
+//	if err != nil {
+//		// Handle error
+//	}
+//	// n is between 1000 and 9999 (inclusive)
+func RangeInt64Closed(min, max int64) (int64, error) {
+	return defaultGenerator.RangeInt64Closed(min, max)
+}
+
+// RangeInt64Closed is like the package-level RangeInt64Closed but draws from g.
+func (g *Generator) RangeInt64Closed(min, max int64) (int64, error) {
+	return rangeClosed(g, min, max)
+}
+
+// RangeUint32Closed returns a cryptographically secure random uint32 in the closed range [min, max].
+// It returns an error if min > max. Unlike the half-open variants, max itself can be
+// returned, so the full range of uint32 can be sampled.
+//
+// Parameters:
+//   - min: the minimum value (inclusive)
+//   - max: the maximum value (inclusive)
+//
+// Returns:
+//   - A random uint32 in [min, max]
+//   - An error if the range is invalid, or if the entropy source fails in strict mode
+//
+// Example:
+//
+//	n, err := rand.RangeUint32Closed(100, 1000)
+//	if err != nil {
+//		// Handle error
+//	}
+//	// n is between 100 and 1000 (inclusive)
+func RangeUint32Closed(min, max uint32) (uint32, error) {
+	return defaultGenerator.RangeUint32Closed(min, max)
+}
+
+// RangeUint32Closed is like the package-level RangeUint32Closed but draws from g.
+func (g *Generator) RangeUint32Closed(min, max uint32) (uint32, error) {
+	return rangeClosed(g, min, max)
+}
+
+// RangeUint64Closed returns a cryptographically secure random uint64 in the closed range [min, max].
+// It returns an error if min > max. Unlike the half-open variants, max itself can be
+// returned, so the full range of uint64 can be sampled.
+//
+// Parameters:
+//   - min: the minimum value (inclusive)
+//   - max: the maximum value (inclusive)
+//
+// Returns:
+//   - A random uint64 in [min, max]
+//   - An error if the range is invalid, or if the entropy source fails in strict mode
+//
+// Example:
+//
+//	n, err := rand.RangeUint64Closed(0, math.MaxUint64)
+//	if err != nil {
+//		// Handle error
+//	}
+//	// n is between 0 and math.MaxUint64 (inclusive)
+func RangeUint64Closed(min, max uint64) (uint64, error) {
+	return defaultGenerator.RangeUint64Closed(min, max)
+}
+
+// RangeUint64Closed is like the package-level RangeUint64Closed but draws from g.
+func (g *Generator) RangeUint64Closed(min, max uint64) (uint64, error) {
+	return rangeClosed(g, min, max)
+}
+
+// RangeBigInt returns a cryptographically secure random integer in the range [min, max)
+// of arbitrary size, such as a scalar modulo the order of an elliptic curve.
+// It returns min if min == max.
+//
+// The value is drawn by rejection sampling so it is exactly uniform, and it follows
+// the same fallback and strict-mode rules as the rest of the package. For secrets,
+// enable strict mode (see SetStrict) so that a predictable value is never returned.
+//
+// Parameters:
+//   - min: the minimum value (inclusive)
+//   - max: the maximum value (exclusive)
+//
+// Returns:
+//   - A new big.Int in [min, max)
+//   - An error if either bound is nil or min > max, or if the entropy source fails in strict mode
+//
+// Example:
+//
+//	// Uniform non-zero scalar for P-256
+//	k, err := rand.RangeBigInt(big.NewInt(1), elliptic.P256().Params().N)
+//	if err != nil {
+//		// Handle error
+//	}
+func RangeBigInt(min, max *big.Int) (*big.Int, error) {
+	return defaultGenerator.RangeBigInt(min, max)
+}
+
+// RangeBigInt is like the package-level RangeBigInt but draws from g.
+func (g *Generator) RangeBigInt(min, max *big.Int) (*big.Int, error) {
+	if min == nil || max == nil || min.Cmp(max) > 0 {
+		return nil, ErrInvalidRange
+	}
+
+	span := new(big.Int).Sub(max, min)
+	if span.Sign() == 0 {
+		return span.Set(min), nil
+	}
+
+	result, err := g.bigIntn(span)
+	if err != nil {
+		return nil, err
+	}
+	return result.Add(result, min), nil
+}
+
+// bigIntn returns a uniform random number in [0, n) for n > 0.
+// Candidates are drawn with exactly the bit length of n-1 and rejected when
+// they are not below n, so fewer than two draws are needed on average.
+func (g *Generator) bigIntn(n *big.Int) (*big.Int, error) {
+	result := new(big.Int).Sub(n, big.NewInt(1))
+	bitLen := result.BitLen()
+	if bitLen == 0 {
+		return result, nil
+	}
+
+	buf := make([]byte, (bitLen+7)/8)
+	topBits := uint(bitLen % 8)
+	if topBits == 0 {
+		topBits = 8
+	}
+
+	for {
+		if err := g.read(buf); err != nil {
+			return nil, err
+		}
+
+		// Clear the excess bits of the most significant byte
+		buf[0] &= byte(1<<topBits - 1)
+		result.SetBytes(buf)
+		if result.Cmp(n) < 0 {
+			return result, nil
+		}
+	}
+}
+
+// Integer is a constraint that permits any integer type, including named types
+// whose underlying type is an integer. It mirrors golang.org/x/exp/constraints.Integer.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// rangeSafe returns a uniform random value in [min, max) for any integer type.
+//
+// The span is computed as uint64(max) - uint64(min): sign extension makes this the
+// exact distance for signed types, so spans wider than the maximum value of T,
+// such as [math.MinInt, math.MaxInt), never overflow.
+func rangeSafe[T Integer](g *Generator, min, max T) (T, error) {
+	if min > max {
+		return 0, ErrInvalidRange
+	}
 
 	if min == max {
-		return min
+		return min, nil
 	}
 
-	rangeSize := max - min
-	maxBig := getBigInt()
-	defer putBigInt(maxBig)
+	result, err := g.uint64n(uint64(max) - uint64(min))
+	if err != nil {
+		return 0, err
+	}
+	return min + T(result), nil
+}
 
-	maxBig.SetUint64(rangeSize)
-	if result, ok := secureRandomBigInt(maxBig); ok {
-		return result.Uint64() + min
+// rangeClosed returns a uniform random value in [min, max] for any integer type.
+func rangeClosed[T Integer](g *Generator, min, max T) (T, error) {
+	if min > max {
+		return 0, ErrInvalidRange
 	}
 
-	// Fallback to pseudo-random generation
-	return getFallbackRand().Uint64()%rangeSize + min
+	result, err := g.uint64Closed(uint64(max) - uint64(min))
+	if err != nil {
+		return 0, err
+	}
+	return min + T(result), nil
+}
+
+// Range returns a cryptographically secure random value of any integer type in [min, max).
+// It returns 0 if min > max, and min if min == max, matching RangeInt.
+//
+// Unlike computing max-min in T, spans wider than the maximum value of T are
+// handled correctly, so Range(math.MinInt, math.MaxInt) is uniform over the whole span.
+// In strict mode (see SetStrict) it panics if the entropy source fails.
+//
+// Example:
+//
+//	n := rand.Range[int8](-10, 10)            // int8 in [-10, 10)
+//	d := rand.Range(time.Second, time.Minute) // time.Duration in [1s, 1m)
+func Range[T Integer](min, max T) T {
+	return RangeWith(defaultGenerator, min, max)
+}
+
+// RangeSafe is like Range but returns ErrInvalidRange if min > max, and an error
+// wrapping ErrEntropyUnavailable if the entropy source fails in strict mode.
+//
+// Example:
+//
+//	n, err := rand.RangeSafe[uint16](1024, 65535)
+//	if err != nil {
+//		// Handle error
+//	}
+func RangeSafe[T Integer](min, max T) (T, error) {
+	return rangeSafe(defaultGenerator, min, max)
+}
+
+// RangeWith is like Range but draws from g.
+func RangeWith[T Integer](g *Generator, min, max T) T {
+	result, err := rangeSafe(g, min, max)
+	if errors.Is(err, ErrInvalidRange) {
+		return 0
+	}
+	return must(result, err)
+}
+
+// RangeSafeWith is like RangeSafe but draws from g.
+func RangeSafeWith[T Integer](g *Generator, min, max T) (T, error) {
+	return rangeSafe(g, min, max)
+}
+
+// N returns a cryptographically secure random value of any integer type in [0, n).
+// It returns 0 if n <= 0. In strict mode (see SetStrict) it panics if the
+// entropy source fails.
+//
+// Example:
+//
+//	i := rand.N(len(items))          // Random index into items
+//	b := rand.N[uint8](255)          // uint8 in [0, 255)
+//	delay := rand.N(5 * time.Second) // Jitter in [0, 5s)
+func N[T Integer](n T) T {
+	return NWith(defaultGenerator, n)
+}
+
+// NSafe is like N but returns ErrInvalidRange if n <= 0, and an error
+// wrapping ErrEntropyUnavailable if the entropy source fails in strict mode.
+func NSafe[T Integer](n T) (T, error) {
+	return NSafeWith(defaultGenerator, n)
+}
+
+// NWith is like N but draws from g.
+func NWith[T Integer](g *Generator, n T) T {
+	result, err := NSafeWith(g, n)
+	if errors.Is(err, ErrInvalidRange) {
+		return 0
+	}
+	return must(result, err)
+}
+
+// NSafeWith is like NSafe but draws from g.
+func NSafeWith[T Integer](g *Generator, n T) (T, error) {
+	if n <= 0 {
+		return 0, ErrInvalidRange
+	}
+	return rangeSafe(g, 0, n)
 }
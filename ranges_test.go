@@ -1,7 +1,9 @@
 package rand
 
 import (
+	"crypto/elliptic"
 	"math"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -251,3 +253,230 @@ func BenchmarkRangeInt64Safe(b *testing.B) {
 		_, _ = RangeInt64Safe(0, 1000)
 	}
 }
+
+// TestRangeClosed validates the closed-interval range functions
+func TestRangeClosed(t *testing.T) {
+	// Both endpoints must be reachable
+	seen := make(map[int]bool)
+	for i := 0; i < 1000; i++ {
+		n, err := RangeIntClosed(1, 6)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, n, 1)
+		assert.LessOrEqual(t, n, 6)
+		seen[n] = true
+	}
+	assert.Len(t, seen, 6, "RangeIntClosed(1, 6) should produce every face of a die")
+
+	// Single value ranges
+	n64, err := RangeInt64Closed(42, 42)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), n64)
+
+	// Invalid ranges
+	_, err = RangeIntClosed(2, 1)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+	_, err = RangeUint32Closed(2, 1)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+
+	// Full domains
+	for i := 0; i < 1000; i++ {
+		_, err := RangeInt64Closed(math.MinInt64, math.MaxInt64)
+		require.NoError(t, err)
+		_, err = RangeUint64Closed(0, math.MaxUint64)
+		require.NoError(t, err)
+		u32, err := RangeUint32Closed(math.MaxUint32-1, math.MaxUint32)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, u32, uint32(math.MaxUint32-1))
+	}
+
+	// The upper bound itself is returned when the source yields all ones
+	g := NewGenerator(constantSource(math.MaxUint64))
+	u64, err := g.RangeUint64Closed(0, math.MaxUint64)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), u64)
+	i64, err := g.RangeInt64Closed(math.MinInt64, math.MaxInt64)
+	require.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64), i64)
+}
+
+// TestRangeGeneric validates Range across integer types
+func TestRangeGeneric(t *testing.T) {
+	// Small types should reach every value of their full span
+	seenInt8 := make(map[int8]bool)
+	for i := 0; i < 20000; i++ {
+		n := Range[int8](math.MinInt8, math.MaxInt8)
+		assert.Less(t, n, int8(math.MaxInt8))
+		seenInt8[n] = true
+	}
+	assert.Len(t, seenInt8, 255, "Range[int8] should cover [-128, 127)")
+
+	seenUint8 := make(map[uint8]bool)
+	for i := 0; i < 20000; i++ {
+		seenUint8[N[uint8](math.MaxUint8)] = true
+	}
+	assert.Len(t, seenUint8, 255, "N[uint8] should cover [0, 255)")
+
+	for i := 0; i < 1000; i++ {
+		i16 := Range[int16](-300, 300)
+		assert.GreaterOrEqual(t, i16, int16(-300))
+		assert.Less(t, i16, int16(300))
+
+		u16 := Range[uint16](1024, 65535)
+		assert.GreaterOrEqual(t, u16, uint16(1024))
+
+		p := Range[uintptr](8, 16)
+		assert.GreaterOrEqual(t, p, uintptr(8))
+		assert.Less(t, p, uintptr(16))
+	}
+
+	// Named types are supported
+	type weekday int
+	d := Range[weekday](0, 7)
+	assert.GreaterOrEqual(t, d, weekday(0))
+	assert.Less(t, d, weekday(7))
+
+	// Edge cases match RangeInt
+	assert.Equal(t, 5, Range(5, 5))
+	assert.Equal(t, 0, Range(10, 5))
+	assert.Equal(t, 0, N(0))
+	assert.Equal(t, 0, N(-3))
+}
+
+// TestRangeGenericOverflow validates spans wider than the maximum value of T
+func TestRangeGenericOverflow(t *testing.T) {
+	negative, positive := 0, 0
+	for i := 0; i < 1000; i++ {
+		n := Range(math.MinInt, math.MaxInt)
+		assert.Less(t, n, math.MaxInt)
+		if n < 0 {
+			negative++
+		} else {
+			positive++
+		}
+	}
+	assert.InDelta(t, 500, negative, 100, "Range(MinInt, MaxInt) should be centred on zero")
+
+	for i := 0; i < 1000; i++ {
+		n := Range[int64](math.MinInt64+1, math.MaxInt64)
+		assert.Greater(t, n, int64(math.MinInt64))
+
+		u := Range[uint64](1, math.MaxUint64)
+		assert.GreaterOrEqual(t, u, uint64(1))
+	}
+
+	// The typed helpers share the same overflow-safe implementation
+	n, err := RangeIntSafe(math.MinInt, math.MaxInt)
+	require.NoError(t, err)
+	assert.Less(t, n, math.MaxInt)
+}
+
+// TestRangeGenericSafe validates the error-returning generic variants
+func TestRangeGenericSafe(t *testing.T) {
+	_, err := RangeSafe[int16](10, 5)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+
+	_, err = NSafe[uint32](0)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+
+	_, err = NSafe(-1)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+
+	n, err := NSafe[int8](1)
+	require.NoError(t, err)
+	assert.Equal(t, int8(0), n)
+
+	strict := NewGenerator(failingSource{}, WithStrict())
+	_, err = RangeSafeWith(strict, 0, 10)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = NSafeWith(strict, 10)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	assert.Panics(t, func() { NWith(strict, 10) })
+
+	// Seeded generators are reproducible through the generic API
+	a, b := NewSeeded(7), NewSeeded(7)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, RangeWith[int16](a, -500, 500), RangeWith[int16](b, -500, 500))
+		assert.Equal(t, NWith[uint8](a, 200), NWith[uint8](b, 200))
+	}
+}
+
+// TestRangeFullDomain validates ranges spanning the whole int64 and uint64 domains
+func TestRangeFullDomain(t *testing.T) {
+	const iterations = 4000
+
+	negative := 0
+	for i := 0; i < iterations; i++ {
+		n, err := RangeInt64Safe(math.MinInt64, math.MaxInt64)
+		require.NoError(t, err)
+		assert.Less(t, n, int64(math.MaxInt64))
+		if n < 0 {
+			negative++
+		}
+	}
+	assert.InDelta(t, iterations/2, negative, iterations/10, "RangeInt64Safe(MinInt64, MaxInt64) should be centred on zero")
+
+	high := 0
+	for i := 0; i < iterations; i++ {
+		u, err := RangeUint64Safe(0, math.MaxUint64)
+		require.NoError(t, err)
+		assert.Less(t, u, uint64(math.MaxUint64))
+		if u > math.MaxUint64/2 {
+			high++
+		}
+	}
+	assert.InDelta(t, iterations/2, high, iterations/10, "RangeUint64Safe(0, MaxUint64) should cover the upper half")
+
+	// Just above the largest positive span
+	for i := 0; i < iterations; i++ {
+		n := RangeInt64(-1, math.MaxInt64)
+		assert.GreaterOrEqual(t, n, int64(-1))
+		n = RangeInt64(math.MinInt64, 1)
+		assert.Less(t, n, int64(1))
+	}
+}
+
+// TestRangeBigInt validates arbitrary-precision ranges
+func TestRangeBigInt(t *testing.T) {
+	// Uniform non-zero scalar modulo the P-256 group order
+	order := elliptic.P256().Params().N
+	one := big.NewInt(1)
+	for i := 0; i < 1000; i++ {
+		k, err := RangeBigInt(one, order)
+		require.NoError(t, err)
+		assert.True(t, k.Cmp(one) >= 0 && k.Cmp(order) < 0, "scalar %s out of range", k)
+	}
+
+	// Small spans reach every value, including negative bounds
+	seen := make(map[int64]bool)
+	for i := 0; i < 1000; i++ {
+		n, err := RangeBigInt(big.NewInt(-3), big.NewInt(3))
+		require.NoError(t, err)
+		seen[n.Int64()] = true
+	}
+	assert.Len(t, seen, 6)
+
+	// Bounds are not modified and the result is a fresh value
+	min, max := big.NewInt(10), big.NewInt(10)
+	n, err := RangeBigInt(min, max)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), n.Int64())
+	assert.NotSame(t, min, n)
+
+	// Invalid ranges
+	_, err = RangeBigInt(big.NewInt(2), big.NewInt(1))
+	assert.ErrorIs(t, err, ErrInvalidRange)
+	_, err = RangeBigInt(nil, big.NewInt(1))
+	assert.ErrorIs(t, err, ErrInvalidRange)
+
+	// Strict generators report entropy failures
+	strict := NewGenerator(failingSource{}, WithStrict())
+	_, err = strict.RangeBigInt(one, order)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+
+	// Seeded generators are reproducible
+	a, err := NewSeeded(9).RangeBigInt(one, order)
+	require.NoError(t, err)
+	b, err := NewSeeded(9).RangeBigInt(one, order)
+	require.NoError(t, err)
+	assert.Equal(t, 0, a.Cmp(b))
+}
@@ -0,0 +1,338 @@
+package rand
+
+import (
+	"bufio"
+	"container/heap"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+)
+
+// Reservoir keeps a uniform random sample of at most k items from a stream
+// of unknown length, using O(k) memory.
+//
+// It implements Li's Algorithm L: once the reservoir is full, it computes how
+// many items to skip before the next replacement, so it draws O(k log(n/k))
+// random numbers for a stream of n items instead of one per item. After any
+// number of Add calls, every item seen so far is in the sample with equal
+// probability. Use WeightedReservoir to sample in proportion to weights.
+//
+// A Reservoir is safe for concurrent use.
+type Reservoir[T any] struct {
+	mu    sync.Mutex
+	g     *Generator
+	k     int
+	items []T
+	seen  uint64
+	w     float64 // Algorithm L's running threshold
+	next  uint64  // index of the next item to enter the reservoir
+}
+
+// NewReservoir returns a Reservoir that keeps up to k items.
+// A reservoir with k <= 0 keeps nothing.
+//
+// Example:
+//
+//	r := rand.NewReservoir[Event](100)
+//	for ev := range events {
+//		r.Add(ev)
+//	}
+//	sample := r.Sample()
+func NewReservoir[T any](k int) *Reservoir[T] {
+	return NewReservoirWith[T](defaultGenerator, k)
+}
+
+// NewReservoirWith is like NewReservoir but draws from g.
+func NewReservoirWith[T any](g *Generator, k int) *Reservoir[T] {
+	if k < 0 {
+		k = 0
+	}
+	return &Reservoir[T]{g: g, k: k, items: make([]T, 0, k)}
+}
+
+// Add offers item to the reservoir. In strict mode (see SetStrict) it panics
+// if the entropy source fails.
+func (r *Reservoir[T]) Add(item T) {
+	if err := r.AddE(item); err != nil {
+		panic(err)
+	}
+}
+
+// AddE is like Add but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+// On error item is not counted as seen.
+func (r *Reservoir[T]) AddE(item T) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.k == 0 {
+		r.seen++
+		return nil
+	}
+
+	switch {
+	case len(r.items) < r.k:
+		if len(r.items) == r.k-1 {
+			// The reservoir becomes full: start skipping
+			if err := r.advance(1); err != nil {
+				return err
+			}
+		}
+		r.items = append(r.items, item)
+	case r.seen == r.next:
+		i, err := r.g.uint64n(uint64(r.k))
+		if err != nil {
+			return err
+		}
+		if err := r.advance(r.w); err != nil {
+			return err
+		}
+		r.items[i] = item
+	}
+
+	r.seen++
+	return nil
+}
+
+// advance multiplies the threshold w by U^(1/k) and schedules the next replacement
+// a geometrically distributed number of items after the current one
+func (r *Reservoir[T]) advance(w float64) error {
+	u, err := r.g.openFloat64()
+	if err != nil {
+		return err
+	}
+	v, err := r.g.openFloat64()
+	if err != nil {
+		return err
+	}
+
+	r.w = w * math.Exp(math.Log(u)/float64(r.k))
+	r.next = saturatingAdd(r.seen+1, math.Floor(math.Log(v)/math.Log1p(-r.w)))
+	return nil
+}
+
+// Sample returns a copy of the current sample, in no particular order.
+// It holds min(k, Seen()) items.
+func (r *Reservoir[T]) Sample() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]T(nil), r.items...)
+}
+
+// Seen returns the number of items offered so far.
+func (r *Reservoir[T]) Seen() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.seen
+}
+
+// WeightedReservoir keeps a weighted random sample of at most k items from a
+// stream of unknown length, using O(k) memory.
+//
+// It implements Efraimidis and Spirakis' A-ExpJ: each item conceptually gets
+// the key u^(1/w) for a uniform u and the k largest keys are kept, which
+// samples without replacement in proportion to the weights. Exponential jumps
+// skip over items that cannot enter the reservoir, so only O(k log(n/k))
+// random numbers are drawn for n items. Keys are handled as logarithms so
+// that very small or very large weights do not underflow.
+//
+// A WeightedReservoir is safe for concurrent use.
+type WeightedReservoir[T any] struct {
+	mu   sync.Mutex
+	g    *Generator
+	k    int
+	heap reservoirHeap[T]
+	seen uint64
+	jump float64 // weight still to be skipped before the next replacement
+}
+
+// reservoirEntry is an item in a WeightedReservoir with the logarithm of its key
+type reservoirEntry[T any] struct {
+	item   T
+	logKey float64
+}
+
+// reservoirHeap is a min-heap of entries ordered by key
+type reservoirHeap[T any] []reservoirEntry[T]
+
+func (h reservoirHeap[T]) Len() int            { return len(h) }
+func (h reservoirHeap[T]) Less(i, j int) bool  { return h[i].logKey < h[j].logKey }
+func (h reservoirHeap[T]) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *reservoirHeap[T]) Push(x interface{}) { *h = append(*h, x.(reservoirEntry[T])) }
+func (h *reservoirHeap[T]) Pop() interface{} {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
+
+// NewWeightedReservoir returns a WeightedReservoir that keeps up to k items.
+// A reservoir with k <= 0 keeps nothing.
+//
+// Example:
+//
+//	r := rand.NewWeightedReservoir[Request](10)
+//	for req := range requests {
+//		_ = r.Add(req, req.Cost)
+//	}
+//	sample := r.Sample()
+func NewWeightedReservoir[T any](k int) *WeightedReservoir[T] {
+	return NewWeightedReservoirWith[T](defaultGenerator, k)
+}
+
+// NewWeightedReservoirWith is like NewWeightedReservoir but draws from g.
+func NewWeightedReservoirWith[T any](g *Generator, k int) *WeightedReservoir[T] {
+	if k < 0 {
+		k = 0
+	}
+	return &WeightedReservoir[T]{g: g, k: k, heap: make(reservoirHeap[T], 0, k)}
+}
+
+// Add offers item with weight w to the reservoir. Items with zero weight are
+// counted as seen but never sampled.
+//
+// It returns an error wrapping ErrInvalidWeights if w is negative, NaN or
+// infinite, and an error wrapping ErrEntropyUnavailable if the entropy source
+// fails in strict mode (see SetStrict).
+func (r *WeightedReservoir[T]) Add(item T, w float64) error {
+	if err := checkWeight(w); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.k == 0 || w == 0 {
+		r.seen++
+		return nil
+	}
+
+	if len(r.heap) < r.k {
+		u, err := r.g.openFloat64()
+		if err != nil {
+			return err
+		}
+		heap.Push(&r.heap, reservoirEntry[T]{item: item, logKey: math.Log(u) / w})
+		if len(r.heap) == r.k {
+			if err := r.scheduleJump(); err != nil {
+				return err
+			}
+		}
+		r.seen++
+		return nil
+	}
+
+	r.jump -= w
+	if r.jump <= 0 {
+		// The new key is drawn conditioned on beating the current minimum:
+		// uniform in (t, 1) with t = T^w, taken to the power 1/w
+		u, err := r.g.openFloat64()
+		if err != nil {
+			return err
+		}
+		oneMinusT := -math.Expm1(w * r.heap[0].logKey)
+		logKey := math.Log1p(-oneMinusT*u) / w
+
+		r.heap[0] = reservoirEntry[T]{item: item, logKey: logKey}
+		heap.Fix(&r.heap, 0)
+		if err := r.scheduleJump(); err != nil {
+			return err
+		}
+	}
+
+	r.seen++
+	return nil
+}
+
+// scheduleJump draws the total weight to skip before the next replacement
+func (r *WeightedReservoir[T]) scheduleJump() error {
+	u, err := r.g.openFloat64()
+	if err != nil {
+		return err
+	}
+	r.jump = math.Log(u) / r.heap[0].logKey
+	return nil
+}
+
+// Sample returns a copy of the current sample, in no particular order.
+func (r *WeightedReservoir[T]) Sample() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items := make([]T, len(r.heap))
+	for i, e := range r.heap {
+		items[i] = e.item
+	}
+	return items
+}
+
+// Seen returns the number of items offered so far, including those with zero weight.
+func (r *WeightedReservoir[T]) Seen() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.seen
+}
+
+// SampleLines returns k lines chosen uniformly from r without holding the
+// whole input in memory, in no particular order. Line endings ("\n" or
+// "\r\n") are removed; a final line without a newline is included. If r has
+// fewer than k lines, all of them are returned.
+//
+// It returns ErrInvalidRange if k < 0, and any error from reading r.
+//
+// Example:
+//
+//	f, _ := os.Open("access.log")
+//	defer f.Close()
+//	lines, err := rand.SampleLines(f, 20)
+func SampleLines(r io.Reader, k int) ([]string, error) {
+	return SampleLinesWith(defaultGenerator, r, k)
+}
+
+// SampleLinesWith is like SampleLines but draws from g.
+func SampleLinesWith(g *Generator, r io.Reader, k int) ([]string, error) {
+	if k < 0 {
+		return nil, ErrInvalidRange
+	}
+
+	res := NewReservoirWith[string](g, k)
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if len(line) > 0 {
+			line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+			if addErr := res.AddE(line); addErr != nil {
+				return nil, addErr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading lines: %w", err)
+		}
+	}
+	return res.Sample(), nil
+}
+
+// openFloat64 returns a uniform random float64 in the open interval (0, 1),
+// as needed by logarithms of uniform variates
+func (g *Generator) openFloat64() (float64, error) {
+	for {
+		u, err := g.Float64E()
+		if err != nil || u > 0 {
+			return u, err
+		}
+	}
+}
+
+// saturatingAdd returns n + skip, clamped to math.MaxUint64
+func saturatingAdd(n uint64, skip float64) uint64 {
+	if skip >= float64(math.MaxUint64-n) {
+		return math.MaxUint64
+	}
+	return n + uint64(skip)
+}
@@ -0,0 +1,203 @@
+package rand
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReservoir validates that every item of a stream is sampled with probability k/n
+func TestReservoir(t *testing.T) {
+	testCases := []struct {
+		n, k, trials int
+	}{
+		{n: 20, k: 5, trials: 20000},
+		{n: 1000, k: 10, trials: 5000}, // Mostly exercises the skips
+	}
+
+	for _, tc := range testCases {
+		const buckets = 10
+		counts := make([]int, buckets)
+		for trial := 0; trial < tc.trials; trial++ {
+			r := NewReservoir[int](tc.k)
+			for i := 0; i < tc.n; i++ {
+				r.Add(i)
+			}
+
+			sample := r.Sample()
+			require.Len(t, sample, tc.k)
+			seen := make(map[int]bool)
+			for _, v := range sample {
+				require.False(t, seen[v], "duplicate item %d", v)
+				seen[v] = true
+				counts[v*buckets/tc.n]++
+			}
+		}
+
+		expected := float64(tc.trials*tc.k) / buckets
+		for b, count := range counts {
+			assert.InDelta(t, expected, count, 5*math.Sqrt(expected), "n=%d k=%d bucket %d", tc.n, tc.k, b)
+		}
+	}
+}
+
+// TestReservoirEdgeCases validates short streams and degenerate sizes
+func TestReservoirEdgeCases(t *testing.T) {
+	r := NewReservoir[string](5)
+	assert.Empty(t, r.Sample())
+	r.Add("a")
+	r.Add("b")
+	sample := r.Sample()
+	sort.Strings(sample)
+	assert.Equal(t, []string{"a", "b"}, sample)
+	assert.Equal(t, uint64(2), r.Seen())
+
+	// The sample is a copy
+	sample[0] = "changed"
+	assert.NotContains(t, r.Sample(), "changed")
+
+	for _, k := range []int{0, -3} {
+		r := NewReservoir[int](k)
+		r.Add(1)
+		assert.Empty(t, r.Sample())
+		assert.Equal(t, uint64(1), r.Seen())
+	}
+
+	// A seeded reservoir is reproducible
+	run := func() []int {
+		r := NewReservoirWith[int](NewSeeded(11), 3)
+		for i := 0; i < 500; i++ {
+			r.Add(i)
+		}
+		return r.Sample()
+	}
+	assert.Equal(t, run(), run())
+
+	strict := NewReservoirWith[int](NewGenerator(failingSource{}, WithStrict()), 1)
+	assert.ErrorIs(t, strict.AddE(1), ErrEntropyUnavailable)
+	assert.Equal(t, uint64(0), strict.Seen())
+	assert.Panics(t, func() { strict.Add(1) })
+}
+
+// TestWeightedReservoir validates that items are sampled in proportion to their weights
+func TestWeightedReservoir(t *testing.T) {
+	const trials = 20000
+
+	// With k = 1 the sample is a single weighted choice
+	counts := make([]int, 4)
+	for trial := 0; trial < trials; trial++ {
+		r := NewWeightedReservoir[int](1)
+		for i, w := range []float64{1, 2, 3, 4} {
+			require.NoError(t, r.Add(i, w))
+		}
+		counts[r.Sample()[0]]++
+	}
+	for i, count := range counts {
+		assert.InDelta(t, trials*float64(i+1)/10, count, 400, "item %d", i)
+	}
+
+	// Long streams go through the exponential jumps
+	heavy := 0
+	for trial := 0; trial < trials/4; trial++ {
+		r := NewWeightedReservoir[int](1)
+		for i := 0; i < 1000; i++ {
+			require.NoError(t, r.Add(i, float64(1+2*(i%2))))
+		}
+		if r.Sample()[0]%2 == 1 {
+			heavy++
+		}
+	}
+	assert.InDelta(t, trials/4*3/4, heavy, 250, "weight-3 items should win 3/4 of the time")
+}
+
+// TestWeightedReservoirEdgeCases validates weights and sizes
+func TestWeightedReservoirEdgeCases(t *testing.T) {
+	r := NewWeightedReservoir[string](3)
+	for _, w := range []float64{-1, math.NaN(), math.Inf(1)} {
+		assert.ErrorIs(t, r.Add("bad", w), ErrInvalidWeights, "Add(%g)", w)
+	}
+	assert.Equal(t, uint64(0), r.Seen())
+
+	// Zero-weight items are never sampled
+	for i := 0; i < 100; i++ {
+		require.NoError(t, r.Add("zero", 0))
+		require.NoError(t, r.Add(fmt.Sprint(i), 1))
+	}
+	assert.Len(t, r.Sample(), 3)
+	assert.NotContains(t, r.Sample(), "zero")
+	assert.Equal(t, uint64(200), r.Seen())
+
+	// Extreme weights do not break the comparison of keys
+	r = NewWeightedReservoir[string](1)
+	require.NoError(t, r.Add("tiny", 1e-300))
+	require.NoError(t, r.Add("huge", 1e300))
+	assert.Equal(t, []string{"huge"}, r.Sample())
+
+	empty := NewWeightedReservoir[int](0)
+	require.NoError(t, empty.Add(1, 1))
+	assert.Empty(t, empty.Sample())
+
+	strict := NewWeightedReservoirWith[int](NewGenerator(failingSource{}, WithStrict()), 1)
+	assert.ErrorIs(t, strict.Add(1, 1), ErrEntropyUnavailable)
+}
+
+// errAfterReader returns its data and then a non-EOF error
+type errAfterReader struct {
+	data io.Reader
+}
+
+func (r errAfterReader) Read(p []byte) (int, error) {
+	n, err := r.data.Read(p)
+	if errors.Is(err, io.EOF) {
+		return n, errors.New("disk on fire")
+	}
+	return n, err
+}
+
+// TestSampleLines validates sampling lines from a reader
+func TestSampleLines(t *testing.T) {
+	lines, err := SampleLines(strings.NewReader("a\r\nb\nc"), 10)
+	require.NoError(t, err)
+	sort.Strings(lines)
+	assert.Equal(t, []string{"a", "b", "c"}, lines)
+
+	var sb strings.Builder
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&sb, "line %d\n", i)
+	}
+	lines, err = SampleLines(strings.NewReader(sb.String()), 5)
+	require.NoError(t, err)
+	assert.Len(t, lines, 5)
+	for _, line := range lines {
+		assert.True(t, strings.HasPrefix(line, "line "), line)
+	}
+
+	a, err := SampleLinesWith(NewSeeded(1), strings.NewReader(sb.String()), 5)
+	require.NoError(t, err)
+	b, err := SampleLinesWith(NewSeeded(1), strings.NewReader(sb.String()), 5)
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	_, err = SampleLines(strings.NewReader("x"), -1)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+
+	_, err = SampleLines(errAfterReader{strings.NewReader("a\nb\n")}, 1)
+	assert.EqualError(t, err, "reading lines: disk on fire")
+}
+
+// BenchmarkReservoir benchmarks Add on a long stream
+func BenchmarkReservoir(b *testing.B) {
+	r := NewReservoir[int](100)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Add(i)
+	}
+}
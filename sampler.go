@@ -0,0 +1,242 @@
+package rand
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// WeightedSampler picks items at random in proportion to weights that can
+// change over time.
+//
+// Weights are kept in a sum tree, a complete binary tree whose leaves hold
+// the weights and whose inner nodes hold the sums of their children, so Set,
+// Add, Remove and Pick all take O(log n) time (Add amortized). Inner sums are
+// recomputed from their children on every update rather than adjusted by
+// differences, so floating-point error does not accumulate however often the
+// weights change. Use WeightedChooser when the weights are fixed.
+//
+// A WeightedSampler is safe for concurrent use.
+type WeightedSampler[T any] struct {
+	mu    sync.RWMutex
+	items []T
+	tree  []float64 // tree[1] is the root; leaf i is tree[leaves+i]
+}
+
+// NewWeightedSampler returns an empty WeightedSampler.
+//
+// Example:
+//
+//	s := rand.NewWeightedSampler[string]()
+//	east, _ := s.Add("us-east", 5)
+//	s.Add("eu", 2)
+//	s.Set(east, 1.5) // React to a latency spike
+//	endpoint := s.Pick()
+func NewWeightedSampler[T any]() *WeightedSampler[T] {
+	return &WeightedSampler[T]{}
+}
+
+// leaves returns the number of leaves, a power of two, or 0 before the first Add
+func (s *WeightedSampler[T]) leaves() int {
+	return len(s.tree) / 2
+}
+
+// checkWeight reports whether w can be used as a weight
+func checkWeight(w float64) error {
+	if math.IsNaN(w) || math.IsInf(w, 0) {
+		return fmt.Errorf("%w: weight is %v", ErrInvalidWeights, w)
+	}
+	if w < 0 {
+		return fmt.Errorf("%w: weight is negative", ErrInvalidWeights)
+	}
+	return nil
+}
+
+// update stores w at leaf i and recomputes the sums on its path to the root.
+// It restores the previous weight and fails if the total would overflow.
+func (s *WeightedSampler[T]) update(i int, w float64) error {
+	node := s.leaves() + i
+	old := s.tree[node]
+
+	s.tree[node] = w
+	for p := node / 2; p >= 1; p /= 2 {
+		s.tree[p] = s.tree[2*p] + s.tree[2*p+1]
+	}
+
+	if math.IsInf(s.tree[1], 0) {
+		_ = s.update(i, old)
+		return fmt.Errorf("%w: total weight too large", ErrInvalidWeights)
+	}
+	return nil
+}
+
+// Add appends item with weight w and returns its index.
+// It returns an error wrapping ErrInvalidWeights if w is negative, NaN or
+// infinite, or if the total weight would overflow.
+func (s *WeightedSampler[T]) Add(item T, w float64) (int, error) {
+	if err := checkWeight(w); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := len(s.items)
+	if i == s.leaves() {
+		s.grow()
+	}
+	if err := s.update(i, w); err != nil {
+		return 0, err
+	}
+	s.items = append(s.items, item)
+	return i, nil
+}
+
+// grow doubles the number of leaves and rebuilds the inner sums
+func (s *WeightedSampler[T]) grow() {
+	old := s.leaves()
+	leaves := 2 * old
+	if leaves == 0 {
+		leaves = 1
+	}
+
+	tree := make([]float64, 2*leaves)
+	copy(tree[leaves:], s.tree[old:])
+	for p := leaves - 1; p >= 1; p-- {
+		tree[p] = tree[2*p] + tree[2*p+1]
+	}
+	s.tree = tree
+}
+
+// Set changes the weight of the item at index i.
+// It returns an error wrapping ErrInvalidRange if i is out of range, or one
+// wrapping ErrInvalidWeights if w is negative, NaN or infinite, or if the
+// total weight would overflow.
+func (s *WeightedSampler[T]) Set(i int, w float64) error {
+	if err := checkWeight(w); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i < 0 || i >= len(s.items) {
+		return fmt.Errorf("%w: index %d outside [0, %d)", ErrInvalidRange, i, len(s.items))
+	}
+	return s.update(i, w)
+}
+
+// Remove deletes the item at index i and returns it. To keep the indices
+// dense, the last item moves into slot i, so its index changes to i.
+// It returns an error wrapping ErrInvalidRange if i is out of range.
+func (s *WeightedSampler[T]) Remove(i int) (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var zero T
+	last := len(s.items) - 1
+	if i < 0 || i > last {
+		return zero, fmt.Errorf("%w: index %d outside [0, %d)", ErrInvalidRange, i, len(s.items))
+	}
+
+	// Both updates only lower the total, so neither can overflow
+	removed := s.items[i]
+	moved := s.tree[s.leaves()+last]
+	_ = s.update(last, 0)
+	if i != last {
+		s.items[i] = s.items[last]
+		_ = s.update(i, moved)
+	}
+	s.items[last] = zero
+	s.items = s.items[:last]
+	return removed, nil
+}
+
+// Len returns the number of items, including those with zero weight.
+func (s *WeightedSampler[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.items)
+}
+
+// Weight returns the weight of the item at index i, or 0 if i is out of range.
+func (s *WeightedSampler[T]) Weight(i int) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if i < 0 || i >= len(s.items) {
+		return 0
+	}
+	return s.tree[s.leaves()+i]
+}
+
+// Total returns the sum of all weights.
+func (s *WeightedSampler[T]) Total() float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.tree) == 0 {
+		return 0
+	}
+	return s.tree[1]
+}
+
+// Pick returns an item chosen with probability proportional to its weight.
+// It returns the zero value of T if the sampler is empty or all weights are
+// zero, matching Choice. In strict mode (see SetStrict) it panics if the
+// entropy source fails.
+func (s *WeightedSampler[T]) Pick() T {
+	return s.PickWith(defaultGenerator)
+}
+
+// PickSafe is like Pick but returns an error wrapping ErrInvalidWeights if no
+// item has a positive weight, and an error wrapping ErrEntropyUnavailable if
+// the entropy source fails in strict mode.
+func (s *WeightedSampler[T]) PickSafe() (T, error) {
+	return s.PickSafeWith(defaultGenerator)
+}
+
+// PickWith is like Pick but draws from g.
+func (s *WeightedSampler[T]) PickWith(g *Generator) T {
+	result, err := s.PickSafeWith(g)
+	if errors.Is(err, ErrInvalidWeights) {
+		return result
+	}
+	return must(result, err)
+}
+
+// PickSafeWith is like PickSafe but draws from g.
+func (s *WeightedSampler[T]) PickSafeWith(g *Generator) (T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var zero T
+	if len(s.tree) == 0 || s.tree[1] == 0 {
+		return zero, fmt.Errorf("%w: no item has a positive weight", ErrInvalidWeights)
+	}
+
+	u, err := g.Float64E()
+	if err != nil {
+		return zero, err
+	}
+	return s.items[s.find(u*s.tree[1])], nil
+}
+
+// find descends the sum tree from the root to the leaf whose cumulative
+// weight interval contains r, going left while r falls within the left sum
+func (s *WeightedSampler[T]) find(r float64) int {
+	node := 1
+	for node < s.leaves() {
+		left, right := s.tree[2*node], s.tree[2*node+1]
+		// Rounding may push r past the left sum into an empty right subtree;
+		// never descend into a subtree without weight
+		if (r < left || right == 0) && left > 0 {
+			node = 2 * node
+		} else {
+			r -= left
+			node = 2*node + 1
+		}
+	}
+	return node - s.leaves()
+}
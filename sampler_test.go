@@ -0,0 +1,205 @@
+package rand
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// samplerCounts picks iterations times and counts the results
+func samplerCounts(s *WeightedSampler[string], iterations int) map[string]int {
+	counts := make(map[string]int)
+	for i := 0; i < iterations; i++ {
+		counts[s.Pick()]++
+	}
+	return counts
+}
+
+// TestWeightedSampler validates picks as weights change
+func TestWeightedSampler(t *testing.T) {
+	const iterations = 100000
+
+	s := NewWeightedSampler[string]()
+	assert.Equal(t, "", s.Pick(), "empty sampler should return the zero value")
+
+	a, err := s.Add("a", 1)
+	require.NoError(t, err)
+	b, err := s.Add("b", 3)
+	require.NoError(t, err)
+	c, err := s.Add("c", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, []int{a, b, c})
+	assert.Equal(t, 3, s.Len())
+	assert.Equal(t, 4.0, s.Total())
+
+	counts := samplerCounts(s, iterations)
+	assert.InDelta(t, 25000, counts["a"], 800)
+	assert.InDelta(t, 75000, counts["b"], 800)
+	assert.Zero(t, counts["c"])
+
+	require.NoError(t, s.Set(b, 0))
+	require.NoError(t, s.Set(c, 1))
+	assert.Equal(t, 0.0, s.Weight(b))
+	counts = samplerCounts(s, iterations)
+	assert.InDelta(t, 50000, counts["a"], 800)
+	assert.Zero(t, counts["b"])
+	assert.InDelta(t, 50000, counts["c"], 800)
+}
+
+// TestWeightedSamplerRemove validates that Remove moves the last item into the gap
+func TestWeightedSamplerRemove(t *testing.T) {
+	s := NewWeightedSampler[string]()
+	for i, name := range []string{"a", "b", "c", "d", "e"} {
+		_, err := s.Add(name, float64(i+1))
+		require.NoError(t, err)
+	}
+
+	removed, err := s.Remove(1)
+	require.NoError(t, err)
+	assert.Equal(t, "b", removed)
+	assert.Equal(t, 4, s.Len())
+	assert.Equal(t, 5.0, s.Weight(1), "last item should take the removed slot")
+	assert.Equal(t, 13.0, s.Total())
+
+	removed, err = s.Remove(3)
+	require.NoError(t, err)
+	assert.Equal(t, "d", removed)
+	assert.Equal(t, 9.0, s.Total())
+
+	counts := samplerCounts(s, 90000)
+	assert.Zero(t, counts["b"])
+	assert.Zero(t, counts["d"])
+	assert.InDelta(t, 10000, counts["a"], 700)
+	assert.InDelta(t, 30000, counts["c"], 700)
+	assert.InDelta(t, 50000, counts["e"], 700)
+
+	// Removing everything leaves nothing to pick
+	for s.Len() > 0 {
+		_, err := s.Remove(0)
+		require.NoError(t, err)
+	}
+	_, err = s.PickSafe()
+	assert.ErrorIs(t, err, ErrInvalidWeights)
+
+	_, err = s.Remove(0)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+}
+
+// TestWeightedSamplerInvalid validates input checks
+func TestWeightedSamplerInvalid(t *testing.T) {
+	s := NewWeightedSampler[int]()
+
+	for _, w := range []float64{-1, math.NaN(), math.Inf(1)} {
+		_, err := s.Add(1, w)
+		assert.ErrorIs(t, err, ErrInvalidWeights, "Add(%g)", w)
+	}
+	assert.Equal(t, 0, s.Len(), "rejected items must not be added")
+
+	_, err := s.Add(1, 0)
+	require.NoError(t, err)
+	_, err = s.PickSafe()
+	assert.ErrorIs(t, err, ErrInvalidWeights, "all-zero weights cannot be picked")
+
+	assert.ErrorIs(t, s.Set(1, 1), ErrInvalidRange)
+	assert.ErrorIs(t, s.Set(-1, 1), ErrInvalidRange)
+	assert.ErrorIs(t, s.Set(0, -2), ErrInvalidWeights)
+
+	// Overflowing the total is rejected and leaves the weights unchanged
+	require.NoError(t, s.Set(0, math.MaxFloat64))
+	_, err = s.Add(2, math.MaxFloat64)
+	assert.ErrorIs(t, err, ErrInvalidWeights)
+	assert.Equal(t, math.MaxFloat64, s.Total())
+	assert.Equal(t, 1, s.Len())
+}
+
+// TestWeightedSamplerNoDrift validates that many updates do not corrupt the sums
+func TestWeightedSamplerNoDrift(t *testing.T) {
+	g := NewSeeded(3)
+	s := NewWeightedSampler[int]()
+	for i := 0; i < 100; i++ {
+		_, err := s.Add(i, g.Float64())
+		require.NoError(t, err)
+	}
+	for i := 0; i < 100000; i++ {
+		require.NoError(t, s.Set(g.RangeInt(0, 100), g.Float64()*1e6))
+	}
+	for i := 0; i < 100; i++ {
+		require.NoError(t, s.Set(i, 0))
+	}
+	require.NoError(t, s.Set(42, 1e-9))
+
+	assert.Equal(t, 1e-9, s.Total())
+	for i := 0; i < 1000; i++ {
+		assert.Equal(t, 42, s.PickWith(g))
+	}
+}
+
+// TestWeightedSamplerConcurrency validates concurrent updates and picks
+func TestWeightedSamplerConcurrency(t *testing.T) {
+	s := NewWeightedSampler[int]()
+	for i := 0; i < 64; i++ {
+		_, err := s.Add(i, 1)
+		require.NoError(t, err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 2000; j++ {
+				_ = s.Set(RangeInt(0, 64), Float64()+0.1)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 2000; j++ {
+				v := s.Pick()
+				assert.True(t, v >= 0 && v < 64)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestWeightedSamplerGenerator validates seeded and strict picks
+func TestWeightedSamplerGenerator(t *testing.T) {
+	s := NewWeightedSampler[string]()
+	_, _ = s.Add("x", 1)
+	_, _ = s.Add("y", 2)
+
+	a, b := NewSeeded(5), NewSeeded(5)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, s.PickWith(a), s.PickWith(b))
+	}
+
+	strict := NewGenerator(failingSource{}, WithStrict())
+	_, err := s.PickSafeWith(strict)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	assert.Panics(t, func() { s.PickWith(strict) })
+}
+
+// BenchmarkWeightedSampler benchmarks picks and updates on a large sampler
+func BenchmarkWeightedSampler(b *testing.B) {
+	s := NewWeightedSampler[int]()
+	for i := 0; i < 10000; i++ {
+		_, _ = s.Add(i, float64(i+1))
+	}
+
+	b.Run("Pick", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = s.Pick()
+		}
+	})
+
+	b.Run("Set", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = s.Set(i%10000, float64(i))
+		}
+	})
+}
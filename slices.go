@@ -0,0 +1,219 @@
+package rand
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrEmptySlice is returned when an element is requested from an empty slice
+var ErrEmptySlice = errors.New("empty slice")
+
+// Go methods cannot have type parameters, so the generic functions in this
+// file take an explicit *Generator in their ...With variants instead.
+
+// Shuffle randomizes the order of the elements of s in place using the
+// Fisher–Yates algorithm. Every permutation is equally likely.
+// In strict mode (see SetStrict) it panics if the entropy source fails.
+//
+// Example:
+//
+//	deck := []string{"A", "K", "Q", "J"}
+//	rand.Shuffle(deck)
+func Shuffle[T any](s []T) {
+	ShuffleWith(defaultGenerator, s)
+}
+
+// ShuffleE is like Shuffle but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+// On error s may be partially shuffled.
+func ShuffleE[T any](s []T) error {
+	return ShuffleEWith(defaultGenerator, s)
+}
+
+// ShuffleWith is like Shuffle but draws from g.
+//
+// Example:
+//
+//	rand.ShuffleWith(rand.NewSeeded(42), deck) // Same order on every run
+func ShuffleWith[T any](g *Generator, s []T) {
+	if err := ShuffleEWith(g, s); err != nil {
+		panic(err)
+	}
+}
+
+// ShuffleEWith is like ShuffleE but draws from g.
+func ShuffleEWith[T any](g *Generator, s []T) error {
+	for i := len(s) - 1; i > 0; i-- {
+		j, err := g.uint64n(uint64(i) + 1)
+		if err != nil {
+			return err
+		}
+		s[i], s[j] = s[j], s[i]
+	}
+	return nil
+}
+
+// Perm returns a random permutation of the integers [0, n).
+// It returns an empty slice if n <= 0.
+//
+// Example:
+//
+//	order := rand.Perm(5) // Returns a value like [3 0 4 1 2]
+func Perm(n int) []int {
+	return defaultGenerator.Perm(n)
+}
+
+// Perm is like the package-level Perm but draws from g.
+func (g *Generator) Perm(n int) []int {
+	return must(g.PermE(n))
+}
+
+// PermE is like Perm but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func PermE(n int) ([]int, error) {
+	return defaultGenerator.PermE(n)
+}
+
+// PermE is like the package-level PermE but draws from g.
+func (g *Generator) PermE(n int) ([]int, error) {
+	if n <= 0 {
+		return []int{}, nil
+	}
+
+	// Inside-out Fisher–Yates builds the permutation in a single pass
+	p := make([]int, n)
+	for i := range p {
+		j, err := g.uint64n(uint64(i) + 1)
+		if err != nil {
+			return nil, err
+		}
+		p[i] = p[j]
+		p[j] = i
+	}
+	return p, nil
+}
+
+// Choice returns a uniformly chosen element of s.
+// It returns the zero value of T if s is empty, matching RangeInt.
+// In strict mode (see SetStrict) it panics if the entropy source fails.
+//
+// Example:
+//
+//	color := rand.Choice([]string{"red", "green", "blue"})
+func Choice[T any](s []T) T {
+	return ChoiceWith(defaultGenerator, s)
+}
+
+// ChoiceSafe is like Choice but returns ErrEmptySlice if s is empty, and an error
+// wrapping ErrEntropyUnavailable if the entropy source fails in strict mode.
+func ChoiceSafe[T any](s []T) (T, error) {
+	return ChoiceSafeWith(defaultGenerator, s)
+}
+
+// ChoiceWith is like Choice but draws from g.
+func ChoiceWith[T any](g *Generator, s []T) T {
+	result, err := ChoiceSafeWith(g, s)
+	if errors.Is(err, ErrEmptySlice) {
+		return result
+	}
+	return must(result, err)
+}
+
+// ChoiceSafeWith is like ChoiceSafe but draws from g.
+func ChoiceSafeWith[T any](g *Generator, s []T) (T, error) {
+	var zero T
+	if len(s) == 0 {
+		return zero, ErrEmptySlice
+	}
+
+	i, err := g.uint64n(uint64(len(s)))
+	if err != nil {
+		return zero, err
+	}
+	return s[i], nil
+}
+
+// Sample returns k distinct elements of s chosen uniformly without replacement,
+// in random order. s is not modified. It returns nil if k < 0 or k > len(s).
+// In strict mode (see SetStrict) it panics if the entropy source fails.
+//
+// Small samples use Floyd's algorithm, which needs only k random numbers and
+// O(k) memory regardless of len(s); larger ones use a partial Fisher–Yates
+// shuffle of a copy of s.
+//
+// Example:
+//
+//	winners := rand.Sample(entrants, 3)
+func Sample[T any](s []T, k int) []T {
+	return SampleWith(defaultGenerator, s, k)
+}
+
+// SampleSafe is like Sample but returns an error wrapping ErrInvalidRange if
+// k < 0 or k > len(s), and an error wrapping ErrEntropyUnavailable if the
+// entropy source fails in strict mode.
+func SampleSafe[T any](s []T, k int) ([]T, error) {
+	return SampleSafeWith(defaultGenerator, s, k)
+}
+
+// SampleWith is like Sample but draws from g.
+func SampleWith[T any](g *Generator, s []T, k int) []T {
+	result, err := SampleSafeWith(g, s, k)
+	if errors.Is(err, ErrInvalidRange) {
+		return nil
+	}
+	return must(result, err)
+}
+
+// SampleSafeWith is like SampleSafe but draws from g.
+func SampleSafeWith[T any](g *Generator, s []T, k int) ([]T, error) {
+	n := len(s)
+	if k < 0 || k > n {
+		return nil, fmt.Errorf("%w: sample size %d outside [0, %d]", ErrInvalidRange, k, n)
+	}
+
+	if k <= n/4 {
+		return sampleFloyd(g, s, k)
+	}
+
+	// Partial Fisher–Yates: the first k slots end up holding a uniform sample
+	result := make([]T, n)
+	copy(result, s)
+	for i := 0; i < k; i++ {
+		j, err := g.uint64n(uint64(n - i))
+		if err != nil {
+			return nil, err
+		}
+		j += uint64(i)
+		result[i], result[j] = result[j], result[i]
+	}
+	return result[:k:k], nil
+}
+
+// sampleFloyd selects k distinct indices of s with Floyd's algorithm and
+// returns the elements in random order
+func sampleFloyd[T any](g *Generator, s []T, k int) ([]T, error) {
+	n := len(s)
+	chosen := make(map[int]struct{}, k)
+	result := make([]T, 0, k)
+
+	for j := n - k; j < n; j++ {
+		t, err := g.uint64n(uint64(j) + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		// If t was already taken, j cannot have been, since every earlier pick is below j
+		i := int(t)
+		if _, ok := chosen[i]; ok {
+			i = j
+		}
+		chosen[i] = struct{}{}
+		result = append(result, s[i])
+	}
+
+	// Floyd's algorithm yields a uniform set but not a uniform order
+	if err := ShuffleEWith(g, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
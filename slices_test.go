@@ -0,0 +1,189 @@
+package rand
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestShuffle validates that Shuffle produces every permutation equally often
+func TestShuffle(t *testing.T) {
+	const iterations = 60000
+
+	counts := make(map[string]int)
+	for i := 0; i < iterations; i++ {
+		s := []int{1, 2, 3}
+		Shuffle(s)
+		counts[fmt.Sprint(s)]++
+	}
+
+	assert.Len(t, counts, 6, "every permutation of 3 elements should appear")
+	for perm, count := range counts {
+		assert.InDelta(t, iterations/6, count, 600, "permutation %s", perm)
+	}
+
+	// Degenerate slices are left untouched
+	Shuffle([]int(nil))
+	one := []string{"a"}
+	Shuffle(one)
+	assert.Equal(t, []string{"a"}, one)
+
+	// Elements are preserved
+	s := Perm(1000)
+	Shuffle(s)
+	sort.Ints(s)
+	for i, v := range s {
+		assert.Equal(t, i, v)
+	}
+}
+
+// TestPerm validates the Perm function
+func TestPerm(t *testing.T) {
+	for _, n := range []int{1, 2, 10, 1000} {
+		p := Perm(n)
+		require.Len(t, p, n)
+		sort.Ints(p)
+		for i, v := range p {
+			assert.Equal(t, i, v)
+		}
+	}
+
+	assert.Empty(t, Perm(0))
+	assert.Empty(t, Perm(-5))
+
+	// Each value lands in each position equally often
+	const iterations = 40000
+	var counts [4][4]int
+	for i := 0; i < iterations; i++ {
+		for pos, v := range Perm(4) {
+			counts[pos][v]++
+		}
+	}
+	for pos := range counts {
+		for v := range counts[pos] {
+			assert.InDelta(t, iterations/4, counts[pos][v], 500, "value %d at position %d", v, pos)
+		}
+	}
+}
+
+// TestChoice validates the Choice function
+func TestChoice(t *testing.T) {
+	const iterations = 30000
+
+	items := []string{"red", "green", "blue"}
+	counts := make(map[string]int)
+	for i := 0; i < iterations; i++ {
+		counts[Choice(items)]++
+	}
+	for _, item := range items {
+		assert.InDelta(t, iterations/3, counts[item], 500, "item %s", item)
+	}
+
+	assert.Equal(t, "", Choice([]string{}))
+	_, err := ChoiceSafe([]int(nil))
+	assert.ErrorIs(t, err, ErrEmptySlice)
+}
+
+// TestSample validates the Sample function for both the Floyd and Fisher–Yates paths
+func TestSample(t *testing.T) {
+	const iterations = 20000
+
+	population := Perm(20)
+	for _, k := range []int{0, 1, 3, 5, 12, 20} {
+		counts := make([]int, len(population))
+		for i := 0; i < iterations; i++ {
+			sample := Sample(population, k)
+			require.Len(t, sample, k)
+
+			seen := make(map[int]bool, k)
+			for _, v := range sample {
+				assert.False(t, seen[v], "Sample(%d) repeated %d", k, v)
+				seen[v] = true
+				counts[v]++
+			}
+		}
+
+		// Every element is included with probability k/n; five standard
+		// deviations keep the 120 checks from failing by chance
+		p := float64(k) / float64(len(population))
+		expected := iterations * p
+		tolerance := 5 * math.Sqrt(iterations*p*(1-p))
+		for v, count := range counts {
+			assert.InDelta(t, expected, count, tolerance, "k=%d element %d", k, v)
+		}
+	}
+
+	// Order within a small sample is uniform too
+	const orderIterations = 30000
+	var firsts [2]int
+	for i := 0; i < orderIterations; i++ {
+		s := Sample([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, 2)
+		if s[0] < s[1] {
+			firsts[0]++
+		} else {
+			firsts[1]++
+		}
+	}
+	assert.InDelta(t, orderIterations/2, firsts[0], 600, "sample order should be random")
+
+	// The input is not modified
+	in := []int{1, 2, 3, 4, 5}
+	Sample(in, 4)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, in)
+
+	assert.Nil(t, Sample(in, 6))
+	assert.Nil(t, Sample(in, -1))
+	_, err := SampleSafe(in, 6)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+}
+
+// TestSlicesSeeded validates that the ...With variants are reproducible
+func TestSlicesSeeded(t *testing.T) {
+	a, b := Perm(50), Perm(50)
+	copy(b, a)
+	ShuffleWith(NewSeeded(42), a)
+	ShuffleWith(NewSeeded(42), b)
+	assert.Equal(t, a, b)
+
+	assert.Equal(t, NewSeeded(1).Perm(30), NewSeeded(1).Perm(30))
+	assert.Equal(t, ChoiceWith(NewSeeded(2), a), ChoiceWith(NewSeeded(2), a))
+	assert.Equal(t, SampleWith(NewSeeded(3), a, 4), SampleWith(NewSeeded(3), a, 4))
+	assert.Equal(t, SampleWith(NewSeeded(3), a, 40), SampleWith(NewSeeded(3), a, 40))
+
+	strict := NewGenerator(failingSource{}, WithStrict())
+	assert.ErrorIs(t, ShuffleEWith(strict, a), ErrEntropyUnavailable)
+	_, err := strict.PermE(5)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = ChoiceSafeWith(strict, a)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = SampleSafeWith(strict, a, 3)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	assert.Panics(t, func() { ShuffleWith(strict, a) })
+}
+
+// BenchmarkShuffle benchmarks the Shuffle function
+func BenchmarkShuffle(b *testing.B) {
+	s := Perm(100)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Shuffle(s)
+	}
+}
+
+// BenchmarkSample benchmarks Sample on both algorithm paths
+func BenchmarkSample(b *testing.B) {
+	s := Perm(10000)
+	for _, k := range []int{10, 5000} {
+		b.Run(fmt.Sprintf("k=%d", k), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = Sample(s, k)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package rand
+
+import (
+	"math/rand"
+)
+
+// MathSource adapts a Generator to math/rand.Source64, so code written
+// against *math/rand.Rand, such as shuffles or third-party simulators, can
+// draw from tsrand instead of math/rand's own generator.
+//
+// A MathSource follows the fallback rules of its Generator: in strict mode
+// (see SetStrict and WithStrict) its methods panic if the entropy source fails.
+// It is safe for concurrent use if the Generator's Source is, although the
+// *math/rand.Rand wrapping it is not.
+type MathSource struct {
+	g *Generator
+}
+
+var _ rand.Source64 = (*MathSource)(nil)
+
+// NewSource returns a math/rand.Source64 backed by the default Generator.
+//
+// Example:
+//
+//	r := mathrand.New(rand.NewSource())
+//	r.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+func NewSource() *MathSource {
+	return defaultGenerator.MathSource()
+}
+
+// MathSource returns a math/rand.Source64 that draws from g.
+//
+// Example:
+//
+//	r := mathrand.New(rand.NewSeeded(42).MathSource()) // Reproducible *math/rand.Rand
+func (g *Generator) MathSource() *MathSource {
+	return &MathSource{g: g}
+}
+
+// Int63 returns a random int64 in [0, 1<<63), as required by math/rand.Source.
+func (s *MathSource) Int63() int64 {
+	return s.g.Int64()
+}
+
+// Uint64 returns 64 random bits, as required by math/rand.Source64.
+func (s *MathSource) Uint64() uint64 {
+	return s.g.Uint64()
+}
+
+// Seed reseeds a Generator created by NewSeeded, or one backed by a PCG,
+// to the state NewSeeded(uint64(seed)) would start from. Cryptographic
+// sources cannot be seeded, so for them Seed does nothing.
+func (s *MathSource) Seed(seed int64) {
+	if p, ok := s.g.src.(*PCG); ok {
+		p.Seed(uint64(seed), 0)
+	}
+}
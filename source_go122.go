@@ -0,0 +1,17 @@
+//go:build go1.22
+
+package rand
+
+import (
+	randv2 "math/rand/v2"
+)
+
+// Generator and MathSource implement math/rand/v2.Source through their Uint64
+// methods, so a *math/rand/v2.Rand can draw from tsrand directly:
+//
+//	r := randv2.New(rand.NewSeeded(42))
+//	r.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+var (
+	_ randv2.Source = (*Generator)(nil)
+	_ randv2.Source = (*MathSource)(nil)
+)
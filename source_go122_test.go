@@ -0,0 +1,28 @@
+//go:build go1.22
+
+package rand
+
+import (
+	randv2 "math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRandV2Source validates that Generators plug into math/rand/v2
+func TestRandV2Source(t *testing.T) {
+	r := randv2.New(NewGenerator(nil))
+	for i := 0; i < 1000; i++ {
+		n := r.IntN(100)
+		assert.GreaterOrEqual(t, n, 0)
+		assert.Less(t, n, 100)
+	}
+
+	// A seeded Generator reproduces math/rand/v2's own PCG
+	ours := randv2.New(NewSeeded(42))
+	theirs := randv2.New(randv2.NewPCG(42, 0))
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, theirs.Uint64(), ours.Uint64())
+	}
+	assert.Equal(t, randv2.New(randv2.NewPCG(42, 0)).Perm(20), randv2.New(NewSeeded(42).MathSource()).Perm(20))
+}
@@ -0,0 +1,61 @@
+package rand
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMathSource validates the math/rand.Source64 adapter
+func TestMathSource(t *testing.T) {
+	r := rand.New(NewSource())
+
+	for i := 0; i < 1000; i++ {
+		n := r.Intn(100)
+		assert.GreaterOrEqual(t, n, 0)
+		assert.Less(t, n, 100)
+
+		f := r.Float64()
+		assert.GreaterOrEqual(t, f, 0.0)
+		assert.Less(t, f, 1.0)
+	}
+
+	perm := r.Perm(50)
+	sort.Ints(perm)
+	for i, v := range perm {
+		assert.Equal(t, i, v)
+	}
+
+	src := NewSource()
+	for i := 0; i < 1000; i++ {
+		assert.GreaterOrEqual(t, src.Int63(), int64(0))
+	}
+}
+
+// TestMathSourceSeeded validates that seeded generators stay reproducible through math/rand
+func TestMathSourceSeeded(t *testing.T) {
+	a := rand.New(NewSeeded(42).MathSource())
+	b := rand.New(NewSeeded(42).MathSource())
+	assert.Equal(t, a.Perm(20), b.Perm(20))
+
+	// Uint64 is the raw PCG stream
+	assert.Equal(t, NewPCG(42, 0).Uint64(), NewSeeded(42).MathSource().Uint64())
+
+	// Seed restarts the stream as if NewSeeded had been called
+	a.Seed(7)
+	assert.Equal(t, rand.New(NewSeeded(7).MathSource()).Int63(), a.Int63())
+
+	// Seeding a cryptographic source is a no-op
+	assert.NotPanics(t, func() { NewSource().Seed(1) })
+}
+
+// TestMathSourceStrict validates that the adapter honours strict mode
+func TestMathSourceStrict(t *testing.T) {
+	r := rand.New(NewGenerator(failingSource{}, WithStrict()).MathSource())
+	assert.Panics(t, func() { r.Int63() })
+
+	r = rand.New(NewGenerator(failingSource{}).MathSource())
+	assert.GreaterOrEqual(t, r.Int63(), int64(0))
+}
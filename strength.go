@@ -0,0 +1,272 @@
+package rand
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"unicode/utf8"
+)
+
+// UUIDEntropy is the number of random bits in a version 4 UUID from UUID:
+// 128 bits less the 4 version and 2 variant bits
+const UUIDEntropy = 122
+
+// charsetEntropy returns the min-entropy and the collision entropy, in bits,
+// of one character drawn by CustomString from charset. Both are log2(k) for
+// k distinct characters; a character listed twice is drawn twice as often,
+// which lowers them.
+func charsetEntropy(charset string) (min, collision float64) {
+	n := utf8.RuneCountInString(charset)
+	if n == 0 {
+		return 0, 0
+	}
+
+	counts := make(map[rune]int)
+	for _, r := range charset {
+		counts[r]++
+	}
+	if len(counts) == n {
+		return math.Log2(float64(n)), math.Log2(float64(n))
+	}
+
+	var most int
+	var sumSq float64
+	for _, c := range counts {
+		if c > most {
+			most = c
+		}
+		p := float64(c) / float64(n)
+		sumSq += p * p
+	}
+	return -math.Log2(float64(most) / float64(n)), -math.Log2(sumSq)
+}
+
+// Entropy returns the entropy in bits of a string of the given length drawn
+// by CustomString from charset: length*log2(k) for k distinct characters.
+// A character listed more than once is drawn more often and makes the string
+// easier to guess, so Entropy then reports the min-entropy, length*-log2(p)
+// for the probability p of the likeliest character, which is what a guesser
+// trying the likeliest strings first faces. Entropies of independently generated parts add up: an ID formatted as
+// "ak_" + LowercaseString(8) + "_" + String(16) carries
+// Entropy("abcdefghijklmnopqrstuvwxyz", 8) + Entropy(NormalLetters, 16) bits,
+// and a UUID carries UUIDEntropy bits.
+//
+// Parameters:
+//   - charset: the character set, as passed to CustomString
+//   - length: the number of characters
+//
+// Returns:
+//   - The entropy in bits; 0 if length <= 0 or charset is empty
+//
+// Example:
+//
+//	bits := rand.Entropy(rand.NormalLetters, 16) // 95.3 bits for String(16)
+func Entropy(charset string, length int) float64 {
+	if length <= 0 {
+		return 0
+	}
+	min, _ := charsetEntropy(charset)
+	return float64(length) * min
+}
+
+// LengthForEntropySafe returns the shortest length of a string drawn from
+// charset whose entropy is at least bits.
+//
+// Parameters:
+//   - charset: the character set, as passed to CustomString
+//   - bits: the target entropy, which must not be NaN or infinite
+//
+// Returns:
+//   - The minimum length; 0 if bits <= 0
+//   - An error wrapping ErrInvalidParameter if bits is NaN or infinite, or if
+//     bits > 0 and charset has fewer than two distinct characters
+//
+// Example:
+//
+//	n, err := rand.LengthForEntropySafe(rand.VisibleLetters, 128) // 23 characters
+//	if err != nil {
+//		// Handle error
+//	}
+func LengthForEntropySafe(charset string, bits float64) (int, error) {
+	min, _ := charsetEntropy(charset)
+	return lengthFor(bits, min)
+}
+
+// LengthForEntropy returns the shortest length of a string drawn from charset
+// whose entropy is at least bits. It returns 0 if the target cannot be
+// reached, matching RangeInt.
+//
+// Example:
+//
+//	token := rand.VisibleString(rand.LengthForEntropy(rand.VisibleLetters, 128))
+func LengthForEntropy(charset string, bits float64) int {
+	return orZero(LengthForEntropySafe(charset, bits))
+}
+
+// lengthFor returns the number of characters of perChar bits each needed to reach bits
+func lengthFor(bits, perChar float64) (int, error) {
+	if !isFinite(bits) {
+		return 0, invalidParameter("bits", bits, "finite")
+	}
+	if bits <= 0 {
+		return 0, nil
+	}
+	if perChar <= 0 {
+		return 0, invalidParameter("bits", bits, "0 for a charset with fewer than two distinct characters")
+	}
+
+	// Allow for rounding in the logarithms: 96 bits from 64 characters is 16, not 17
+	n := math.Ceil(bits/perChar - 1e-9)
+	if n > math.MaxInt32 {
+		return 0, invalidParameter("bits", bits, "reachable with fewer than 2^31 characters")
+	}
+	return int(n), nil
+}
+
+// CollisionProbability returns the probability that at least two of n
+// values, drawn independently and uniformly from 2^bits possibilities,
+// are equal. It uses the birthday bound 1 - exp(-n(n-1)/2^(bits+1)), which is
+// accurate whenever the probability is small enough to matter.
+//
+// Example:
+//
+//	// A billion String(16) tokens collide with probability about 1e-11
+//	p := rand.CollisionProbability(rand.Entropy(rand.NormalLetters, 16), 1e9)
+func CollisionProbability(bits float64, n float64) float64 {
+	if !(n >= 2) || math.IsNaN(bits) {
+		return 0
+	}
+	pairs := n * (n - 1) / 2
+	return -math.Expm1(-pairs * math.Exp2(-bits))
+}
+
+// LengthForCollisionSafe returns the shortest length of strings drawn from
+// charset such that n of them are all distinct except with probability at
+// most p.
+//
+// Parameters:
+//   - charset: the character set, as passed to CustomString
+//   - n: the number of strings that will be generated, at least 0
+//   - p: the acceptable collision probability, in (0, 1)
+//
+// Returns:
+//   - The minimum length; 0 if n < 2
+//   - An error wrapping ErrInvalidParameter if a parameter is out of its
+//     domain or charset has fewer than two distinct characters
+//
+// Example:
+//
+//	// Short-link IDs: a billion links with a one-in-a-million collision risk
+//	length, err := rand.LengthForCollisionSafe(rand.VisibleLetters, 1e9, 1e-6) // 14 characters
+//	if err != nil {
+//		// Handle error
+//	}
+func LengthForCollisionSafe(charset string, n, p float64) (int, error) {
+	if !isFinite(n) || n < 0 {
+		return 0, invalidParameter("n", n, "finite and >= 0")
+	}
+	if !(p > 0 && p < 1) {
+		return 0, invalidParameter("p", p, "in (0, 1)")
+	}
+	if n < 2 {
+		return 0, nil
+	}
+
+	// Invert the birthday bound: the space must hold 2^bits >= pairs / -ln(1-p)
+	// values, where collisions are counted with the collision entropy of the charset
+	bits := math.Log2(n*(n-1)/2) - math.Log2(-math.Log1p(-p))
+	_, collision := charsetEntropy(charset)
+	return lengthFor(bits, collision)
+}
+
+// LengthForCollision returns the shortest length of strings drawn from charset
+// such that n of them collide with probability at most p. It returns 0 if a
+// parameter is invalid, matching RangeInt.
+//
+// Example:
+//
+//	id := rand.VisibleString(rand.LengthForCollision(rand.VisibleLetters, 1e9, 1e-6))
+func LengthForCollision(charset string, n, p float64) int {
+	return orZero(LengthForCollisionSafe(charset, n, p))
+}
+
+// StringWithEntropy generates a cryptographically secure random alphanumeric
+// string, like String, just long enough to carry at least bits of entropy.
+// It returns an empty string if bits is not positive, not finite, or so large
+// that the string would need 2^31 characters or more; StringWithEntropySafe
+// reports those as errors.
+//
+// Example:
+//
+//	token := rand.StringWithEntropy(128) // 22 characters
+func StringWithEntropy(bits float64) string {
+	return defaultGenerator.StringWithEntropy(bits)
+}
+
+// StringWithEntropy is like the package-level StringWithEntropy but draws from g.
+func (g *Generator) StringWithEntropy(bits float64) string {
+	s, err := g.StringWithEntropySafe(bits)
+	if errors.Is(err, ErrInvalidParameter) {
+		return ""
+	}
+	return must(s, err)
+}
+
+// StringWithEntropyE is like StringWithEntropy but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func StringWithEntropyE(bits float64) (string, error) {
+	return defaultGenerator.StringWithEntropyE(bits)
+}
+
+// StringWithEntropyE is like the package-level StringWithEntropyE but draws from g.
+func (g *Generator) StringWithEntropyE(bits float64) (string, error) {
+	s, err := g.StringWithEntropySafe(bits)
+	if errors.Is(err, ErrInvalidParameter) {
+		return "", nil
+	}
+	return s, err
+}
+
+// StringWithEntropySafe generates a cryptographically secure random
+// alphanumeric string just long enough to carry at least bits of entropy.
+//
+// Parameters:
+//   - bits: the target entropy, which must be positive and finite
+//
+// Returns:
+//   - A random string of 0-9, a-z and A-Z
+//   - An error wrapping ErrInvalidParameter if bits is not positive, not
+//     finite, or so large that the string would need 2^31 characters or
+//     more, or an error if the entropy source fails in strict mode
+//
+// Example:
+//
+//	token, err := rand.StringWithEntropySafe(128) // 22 characters
+//	if err != nil {
+//		// Handle error
+//	}
+func StringWithEntropySafe(bits float64) (string, error) {
+	return defaultGenerator.StringWithEntropySafe(bits)
+}
+
+// StringWithEntropySafe is like the package-level StringWithEntropySafe but draws from g.
+func (g *Generator) StringWithEntropySafe(bits float64) (string, error) {
+	if !(bits > 0) {
+		return "", invalidParameter("bits", bits, "> 0")
+	}
+	length, err := LengthForEntropySafe(NormalLetters, bits)
+	if err != nil {
+		return "", err
+	}
+	return g.StringE(length)
+}
+
+// log2Big returns the base-2 logarithm of n > 0
+func log2Big(n *big.Int) float64 {
+	bitLen := n.BitLen()
+	if bitLen <= 64 {
+		return math.Log2(float64(n.Uint64()))
+	}
+	top := new(big.Int).Rsh(n, uint(bitLen-64))
+	return math.Log2(float64(top.Uint64())) + float64(bitLen-64)
+}
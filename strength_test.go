@@ -0,0 +1,184 @@
+package rand
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEntropy checks charset entropies, including duplicated characters
+func TestEntropy(t *testing.T) {
+	assert.InDelta(t, 16*math.Log2(62), Entropy(NormalLetters, 16), 1e-9)
+	assert.InDelta(t, 8*math.Log2(55), Entropy(VisibleLetters, 8), 1e-9)
+	assert.InDelta(t, 24, Entropy("0123456789abcdef", 6), 1e-12)
+	assert.InDelta(t, 10*math.Log2(5), Entropy("αβγδε", 10), 1e-12)
+
+	// "aab" draws a with probability 2/3: a guesser trying "aaaa" first
+	// succeeds with probability (2/3)^4 = 2^-2.34, far above the 2^-3.67 the
+	// Shannon entropy would suggest
+	assert.InDelta(t, 4*math.Log2(1.5), Entropy("aab", 4), 1e-12)
+	shannon := -(2.0/3)*math.Log2(2.0/3) - (1.0/3)*math.Log2(1.0/3)
+	assert.Less(t, Entropy("aab", 4), 4*shannon)
+
+	assert.Equal(t, 0.0, Entropy("", 10))
+	assert.Equal(t, 0.0, Entropy("aaaa", 10))
+	assert.Equal(t, 0.0, Entropy(NormalLetters, 0))
+	assert.Equal(t, 0.0, Entropy(NormalLetters, -3))
+}
+
+// TestLengthForEntropy checks minimum lengths, including exact multiples
+func TestLengthForEntropy(t *testing.T) {
+	assert.Equal(t, 22, LengthForEntropy(NormalLetters, 128))
+	assert.Equal(t, 23, LengthForEntropy(VisibleLetters, 128))
+	assert.Equal(t, 16, LengthForEntropy("0123456789abcdef", 64))
+	assert.Equal(t, 17, LengthForEntropy("0123456789abcdef", 64.01))
+	assert.Equal(t, 0, LengthForEntropy(NormalLetters, 0))
+	assert.Equal(t, 0, LengthForEntropy(NormalLetters, -5))
+
+	for _, bits := range []float64{1, 50, 128, 256} {
+		for _, charset := range []string{NormalLetters, "aaab"} {
+			n := LengthForEntropy(charset, bits)
+			assert.GreaterOrEqual(t, Entropy(charset, n), bits)
+			assert.Less(t, Entropy(charset, n-1), bits)
+		}
+	}
+
+	// "aaab" is guessed like a string of 0.415 bits per character, not the
+	// 0.811 of its Shannon entropy
+	assert.Equal(t, 155, LengthForEntropy("aaab", 64))
+
+	for _, tc := range []struct {
+		charset string
+		bits    float64
+	}{
+		{NormalLetters, math.NaN()}, {NormalLetters, math.Inf(1)}, {"", 10}, {"zzz", 10}, {"01", 1e12},
+	} {
+		_, err := LengthForEntropySafe(tc.charset, tc.bits)
+		assert.ErrorIs(t, err, ErrInvalidParameter, "LengthForEntropySafe(%q, %g)", tc.charset, tc.bits)
+	}
+}
+
+// TestCollision checks the birthday bound and its inversion
+func TestCollision(t *testing.T) {
+	// 23 people share a birthday with probability about 1/2
+	assert.InDelta(t, 0.5, CollisionProbability(math.Log2(365), 23), 0.01)
+	assert.InDelta(t, 1.05e-11, CollisionProbability(Entropy(NormalLetters, 16), 1e9), 0.01e-11)
+	assert.Equal(t, 0.0, CollisionProbability(10, 1))
+	assert.InDelta(t, 1, CollisionProbability(10, 1e6), 1e-12)
+
+	n := LengthForCollision(VisibleLetters, 1e9, 1e-6)
+	assert.Equal(t, 14, n)
+	assert.LessOrEqual(t, CollisionProbability(Entropy(VisibleLetters, n), 1e9), 1e-6)
+	assert.Greater(t, CollisionProbability(Entropy(VisibleLetters, n-1), 1e9), 1e-6)
+
+	// Duplicates make collisions likelier
+	assert.Greater(t, LengthForCollision("aaaaaaab", 1e6, 1e-3), LengthForCollision("ab", 1e6, 1e-3))
+
+	assert.Equal(t, 0, LengthForCollision(NormalLetters, 1, 0.5))
+	for _, tc := range [][2]float64{{-1, 0.5}, {math.NaN(), 0.5}, {10, 0}, {10, 1}, {10, math.NaN()}} {
+		_, err := LengthForCollisionSafe(NormalLetters, tc[0], tc[1])
+		assert.ErrorIs(t, err, ErrInvalidParameter, "LengthForCollisionSafe(%g, %g)", tc[0], tc[1])
+	}
+	_, err := LengthForCollisionSafe("x", 10, 0.5)
+	assert.ErrorIs(t, err, ErrInvalidParameter)
+}
+
+// TestStringWithEntropy checks lengths and strict-mode errors
+func TestStringWithEntropy(t *testing.T) {
+	assert.Len(t, StringWithEntropy(128), 22)
+	assert.Len(t, StringWithEntropy(1), 1)
+	assert.Len(t, StringWithEntropy(0.5), 1)
+
+	// Invalid targets are reported by the Safe variant only
+	for _, bits := range []float64{0, -8, math.NaN(), math.Inf(1), math.Inf(-1), 1e12, math.MaxFloat64} {
+		assert.Empty(t, StringWithEntropy(bits), "bits = %g", bits)
+		s, err := StringWithEntropySafe(bits)
+		assert.ErrorIs(t, err, ErrInvalidParameter, "bits = %g", bits)
+		assert.Empty(t, s, "bits = %g", bits)
+		s, err = StringWithEntropyE(bits)
+		assert.NoError(t, err, "bits = %g", bits)
+		assert.Empty(t, s, "bits = %g", bits)
+	}
+	s, err := StringWithEntropySafe(96)
+	require.NoError(t, err)
+	assert.Len(t, s, 17)
+
+	s = NewSeeded(14).StringWithEntropy(64)
+	assert.Equal(t, s, NewSeeded(14).StringWithEntropy(64))
+	for _, r := range s {
+		assert.Contains(t, NormalLetters, string(r))
+	}
+
+	strict := NewGenerator(failingSource{}, WithStrict())
+	_, err = strict.StringWithEntropyE(64)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	_, err = strict.StringWithEntropySafe(64)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+}
+
+// TestPasswordPolicyEntropy compares policy entropies with brute-force counts
+func TestPasswordPolicyEntropy(t *testing.T) {
+	for name, tc := range map[string]struct {
+		policy   PasswordPolicy
+		alphabet string
+	}{
+		"minimums": {
+			PasswordPolicy{Length: 4, MinUpper: 1, MinDigits: 2, Symbols: "!", Exclude: excludeAllBut("ABa12")},
+			"ABa12!",
+		},
+		"no repeat": {
+			PasswordPolicy{Length: 5, MinLower: 2, NoRepeat: true, Symbols: "#", Exclude: excludeAllBut("abX7")},
+			"abX7#",
+		},
+		"no sequential": {
+			PasswordPolicy{Length: 5, MinDigits: 1, NoSequential: true, NoSymbols: true, Exclude: excludeAllBut("abc123")},
+			"abc123",
+		},
+		"both, across classes": {
+			// "/01" and "9:;" are sequences spanning symbols and digits
+			PasswordPolicy{Length: 5, MinSymbols: 1, NoRepeat: true, NoSequential: true, Symbols: "/:;", Exclude: excludeAllBut("0189ab")},
+			"0189ab/:;",
+		},
+	} {
+		valid := enumerate([]rune(tc.alphabet), tc.policy.Length, func(s []rune) bool { return satisfies(tc.policy, s) })
+		require.NotEmpty(t, valid, name)
+
+		bits, err := tc.policy.Entropy()
+		require.NoError(t, err, name)
+		assert.InDelta(t, math.Log2(float64(len(valid))), bits, 1e-9, name)
+	}
+
+	// Without rules, a policy is as strong as a plain string
+	bits, err := PasswordPolicy{Length: 20}.Entropy()
+	require.NoError(t, err)
+	assert.InDelta(t, Entropy(NormalLetters+PasswordSymbols, 20), bits, 1e-9)
+
+	// The default policy loses little to its minimums
+	bits, err = DefaultPasswordPolicy().Entropy()
+	require.NoError(t, err)
+	assert.InDelta(t, 104, bits, 1)
+	assert.Less(t, bits, Entropy(NormalLetters+PasswordSymbols, 16))
+
+	// Long policies with every rule stay consistent with the unconstrained count
+	p := PasswordPolicy{Length: 40, MinUpper: 4, MinLower: 4, MinDigits: 4, MinSymbols: 4, NoRepeat: true, NoSequential: true}
+	bits, err = p.Entropy()
+	require.NoError(t, err)
+	p.NoSequential = false
+	withSequences, err := p.Entropy()
+	require.NoError(t, err)
+	assert.Less(t, bits, withSequences)
+	assert.Greater(t, bits, withSequences-1)
+
+	_, err = PasswordPolicy{Length: 2, MinDigits: 3}.Entropy()
+	assert.ErrorIs(t, err, ErrInvalidPolicy)
+}
+
+func TestLog2Big(t *testing.T) {
+	assert.Equal(t, 0.0, log2Big(big.NewInt(1)))
+	assert.InDelta(t, math.Log2(1000), log2Big(big.NewInt(1000)), 1e-12)
+	n := new(big.Int).Exp(big.NewInt(3), big.NewInt(500), nil)
+	assert.InDelta(t, 500*math.Log2(3), log2Big(n), 1e-9)
+}
@@ -10,7 +10,8 @@ import (
 // randStringFromCharset generates a cryptographically secure random string of specified length
 // using characters from the given charset.
 //
-// This function uses crypto/rand for secure random generation with fallback to math/rand.
+// This function draws from the generator's Source with fallback to math/rand,
+// unless the generator is strict.
 // It uses an optimized approach with pre-computed charset length and efficient string building.
 //
 // Parameters:
@@ -19,37 +20,56 @@ import (
 //
 // Returns:
 //   - A random string of the specified length using characters from the charset
-func randStringFromCharset(charset string, length int) string {
+//   - An error if the Source of a strict generator fails
+func (g *Generator) randStringFromCharset(charset string, length int) (string, error) {
 	if length <= 0 {
-		return ""
+		return "", nil
 	}
 
 	charsetLen := utf8.RuneCountInString(charset)
 	if charsetLen == 0 {
-		return ""
+		return "", nil
+	}
+
+	// Single-byte charsets can be indexed directly and written into one allocation
+	if charsetLen == len(charset) {
+		return g.randStringFromBytes(charset, length)
 	}
 
 	// Convert charset to rune slice for proper Unicode support
-	charsetRunes := []rune(charset)
-	charsetLen = len(charsetRunes)
+	return g.randStringFromRunes([]rune(charset), length)
+}
+
+// randStringFromBytes generates a random string of length > 0 from a
+// non-empty charset of single-byte characters
+func (g *Generator) randStringFromBytes(charset string, length int) (string, error) {
+	var sb strings.Builder
+	sb.Grow(length)
+	for i := 0; i < length; i++ {
+		idx, err := g.uint64n(uint64(len(charset)))
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(charset[idx])
+	}
+	return sb.String(), nil
+}
 
+// randStringFromRunes generates a random string of length > 0 from a
+// non-empty charset of runes
+func (g *Generator) randStringFromRunes(charset []rune, length int) (string, error) {
 	// Pre-allocate the result slice for better performance
 	result := make([]rune, length)
 
-	maxBig := getBigInt()
-	defer putBigInt(maxBig)
-	maxBig.SetInt64(int64(charsetLen))
-
 	for i := 0; i < length; i++ {
-		if randResult, ok := secureRandomBigInt(maxBig); ok {
-			result[i] = charsetRunes[randResult.Int64()]
-		} else {
-			// Fallback to pseudo-random generation
-			result[i] = charsetRunes[getFallbackRand().Intn(charsetLen)]
+		idx, err := g.uint64n(uint64(len(charset)))
+		if err != nil {
+			return "", err
 		}
+		result[i] = charset[idx]
 	}
 
-	return string(result)
+	return string(result), nil
 }
 
 // String generates a cryptographically secure random string of the specified length
@@ -68,7 +88,23 @@ func randStringFromCharset(charset string, length int) string {
 //
 //	s := rand.String(10) // Returns something like "aB3xY9mK7Q"
 func String(length int) string {
-	return randStringFromCharset(NormalLetters, length)
+	return defaultGenerator.String(length)
+}
+
+// String is like the package-level String but draws from g.
+func (g *Generator) String(length int) string {
+	return must(g.StringE(length))
+}
+
+// StringE is like String but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func StringE(length int) (string, error) {
+	return defaultGenerator.StringE(length)
+}
+
+// StringE is like the package-level StringE but draws from g.
+func (g *Generator) StringE(length int) (string, error) {
+	return g.randStringFromCharset(NormalLetters, length)
 }
 
 // VisibleString generates a cryptographically secure random string of the specified length
@@ -91,7 +127,23 @@ func String(length int) string {
 //
 //	s := rand.VisibleString(8) // Returns something like "a8Bx9mKQ" (no confusing chars)
 func VisibleString(length int) string {
-	return randStringFromCharset(VisibleLetters, length)
+	return defaultGenerator.VisibleString(length)
+}
+
+// VisibleString is like the package-level VisibleString but draws from g.
+func (g *Generator) VisibleString(length int) string {
+	return must(g.VisibleStringE(length))
+}
+
+// VisibleStringE is like VisibleString but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func VisibleStringE(length int) (string, error) {
+	return defaultGenerator.VisibleStringE(length)
+}
+
+// VisibleStringE is like the package-level VisibleStringE but draws from g.
+func (g *Generator) VisibleStringE(length int) (string, error) {
+	return g.randStringFromCharset(VisibleLetters, length)
 }
 
 // CustomString generates a cryptographically secure random string of the specified length
@@ -99,6 +151,8 @@ func VisibleString(length int) string {
 //
 // This function allows you to specify your own character set for random string generation.
 // The function uses crypto/rand for secure random generation with fallback to math/rand.
+// A character listed twice is drawn twice as often; use a Charset with StringFrom
+// to have duplicates removed and the set prepared once rather than on every call.
 //
 // Parameters:
 //   - charset: the custom character set to use for generation
@@ -111,7 +165,23 @@ func VisibleString(length int) string {
 //
 //	s := rand.CustomString("ABCDEF0123456789", 8) // Hexadecimal-style string
 func CustomString(charset string, length int) string {
-	return randStringFromCharset(charset, length)
+	return defaultGenerator.CustomString(charset, length)
+}
+
+// CustomString is like the package-level CustomString but draws from g.
+func (g *Generator) CustomString(charset string, length int) string {
+	return must(g.CustomStringE(charset, length))
+}
+
+// CustomStringE is like CustomString but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func CustomStringE(charset string, length int) (string, error) {
+	return defaultGenerator.CustomStringE(charset, length)
+}
+
+// CustomStringE is like the package-level CustomStringE but draws from g.
+func (g *Generator) CustomStringE(charset string, length int) (string, error) {
+	return g.randStringFromCharset(charset, length)
 }
 
 // AlphaString generates a cryptographically secure random string of the specified length
@@ -127,8 +197,24 @@ func CustomString(charset string, length int) string {
 //
 //	s := rand.AlphaString(10) // Returns something like "aBxYmKqWeR"
 func AlphaString(length int) string {
+	return defaultGenerator.AlphaString(length)
+}
+
+// AlphaString is like the package-level AlphaString but draws from g.
+func (g *Generator) AlphaString(length int) string {
+	return must(g.AlphaStringE(length))
+}
+
+// AlphaStringE is like AlphaString but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func AlphaStringE(length int) (string, error) {
+	return defaultGenerator.AlphaStringE(length)
+}
+
+// AlphaStringE is like the package-level AlphaStringE but draws from g.
+func (g *Generator) AlphaStringE(length int) (string, error) {
 	const alphaChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
-	return randStringFromCharset(alphaChars, length)
+	return g.randStringFromCharset(alphaChars, length)
 }
 
 // NumericString generates a cryptographically secure random string of the specified length
@@ -144,8 +230,24 @@ func AlphaString(length int) string {
 //
 //	s := rand.NumericString(6) // Returns something like "138947"
 func NumericString(length int) string {
+	return defaultGenerator.NumericString(length)
+}
+
+// NumericString is like the package-level NumericString but draws from g.
+func (g *Generator) NumericString(length int) string {
+	return must(g.NumericStringE(length))
+}
+
+// NumericStringE is like NumericString but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func NumericStringE(length int) (string, error) {
+	return defaultGenerator.NumericStringE(length)
+}
+
+// NumericStringE is like the package-level NumericStringE but draws from g.
+func (g *Generator) NumericStringE(length int) (string, error) {
 	const numericChars = "0123456789"
-	return randStringFromCharset(numericChars, length)
+	return g.randStringFromCharset(numericChars, length)
 }
 
 // LowercaseString generates a cryptographically secure random string of the specified length
@@ -161,8 +263,24 @@ func NumericString(length int) string {
 //
 //	s := rand.LowercaseString(8) // Returns something like "abxymkqw"
 func LowercaseString(length int) string {
+	return defaultGenerator.LowercaseString(length)
+}
+
+// LowercaseString is like the package-level LowercaseString but draws from g.
+func (g *Generator) LowercaseString(length int) string {
+	return must(g.LowercaseStringE(length))
+}
+
+// LowercaseStringE is like LowercaseString but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func LowercaseStringE(length int) (string, error) {
+	return defaultGenerator.LowercaseStringE(length)
+}
+
+// LowercaseStringE is like the package-level LowercaseStringE but draws from g.
+func (g *Generator) LowercaseStringE(length int) (string, error) {
 	const lowercaseChars = "abcdefghijklmnopqrstuvwxyz"
-	return randStringFromCharset(lowercaseChars, length)
+	return g.randStringFromCharset(lowercaseChars, length)
 }
 
 // UppercaseString generates a cryptographically secure random string of the specified length
@@ -178,14 +296,31 @@ func LowercaseString(length int) string {
 //
 //	s := rand.UppercaseString(8) // Returns something like "ABXYMKQW"
 func UppercaseString(length int) string {
+	return defaultGenerator.UppercaseString(length)
+}
+
+// UppercaseString is like the package-level UppercaseString but draws from g.
+func (g *Generator) UppercaseString(length int) string {
+	return must(g.UppercaseStringE(length))
+}
+
+// UppercaseStringE is like UppercaseString but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func UppercaseStringE(length int) (string, error) {
+	return defaultGenerator.UppercaseStringE(length)
+}
+
+// UppercaseStringE is like the package-level UppercaseStringE but draws from g.
+func (g *Generator) UppercaseStringE(length int) (string, error) {
 	const uppercaseChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
-	return randStringFromCharset(uppercaseChars, length)
+	return g.randStringFromCharset(uppercaseChars, length)
 }
 
 // UUID generates a cryptographically secure Version 4 UUID string.
 //
 // This function attempts to generate a Version 4 (random) UUID using crypto/rand.
-// If that fails, it falls back to a Version 1 (time-based) UUID.
+// If that fails, it falls back to a Version 1 (time-based) UUID, unless strict
+// mode is enabled (see SetStrict), in which case it panics.
 // The returned UUID follows the standard format: xxxxxxxx-xxxx-4xxx-yxxx-xxxxxxxxxxxx
 //
 // Returns:
@@ -195,37 +330,71 @@ func UppercaseString(length int) string {
 //
 //	id := rand.UUID() // Returns something like "550e8400-e29b-41d4-a716-446655440000"
 func UUID() string {
+	return defaultGenerator.UUID()
+}
+
+// UUID is like the package-level UUID but reads the random bits from g's Source.
+func (g *Generator) UUID() string {
+	return must(g.UUIDE())
+}
+
+// UUIDE is like UUID but returns an error instead of falling back to a time-based
+// or pseudo-random UUID when the entropy source fails. Errors are only reported
+// in strict mode (see SetStrict).
+func UUIDE() (string, error) {
+	return defaultGenerator.UUIDE()
+}
+
+// UUIDE is like the package-level UUIDE but reads the random bits from g's Source.
+func (g *Generator) UUIDE() (string, error) {
 	// Attempt to generate a Version 4 (random) UUID
-	if u, err := uuid.NewRandom(); err == nil {
-		return u.String()
+	u, err := uuid.NewRandomFromReader(g.src)
+	if err == nil {
+		return u.String(), nil
+	}
+	if g.isStrict() {
+		return "", entropyError(err)
 	}
 
 	// Fallback to Version 1 (time-based) UUID
-	// This should rarely happen as uuid.NewRandom() is very reliable
+	// This should rarely happen as the Source is expected to be very reliable
 	if u, err := uuid.NewUUID(); err == nil {
-		return u.String()
+		return u.String(), nil
 	}
 
 	// Final fallback: generate a pseudo-UUID using our own random functions
 	// This maintains the UUID format but may not be standards-compliant
-	return generateFallbackUUID()
+	return g.generateFallbackUUID()
 }
 
 // generateFallbackUUID creates a pseudo-UUID when the uuid package fails entirely.
 // This is a rare fallback scenario that maintains UUID format.
-func generateFallbackUUID() string {
+func (g *Generator) generateFallbackUUID() (string, error) {
 	const hexChars = "0123456789abcdef"
 
+	// Each part is drawn with the same fallback rules as the rest of the generator
 	var parts []string
-	parts = append(parts, randStringFromCharset(hexChars, 8))     // 8 hex chars
-	parts = append(parts, randStringFromCharset(hexChars, 4))     // 4 hex chars
-	parts = append(parts, "4"+randStringFromCharset(hexChars, 3)) // Version 4 + 3 hex chars
+	var err error
+	hex := func(charset string, n int) string {
+		var s string
+		if err == nil {
+			s, err = g.randStringFromCharset(charset, n)
+		}
+		return s
+	}
+
+	parts = append(parts, hex(hexChars, 8))     // 8 hex chars
+	parts = append(parts, hex(hexChars, 4))     // 4 hex chars
+	parts = append(parts, "4"+hex(hexChars, 3)) // Version 4 + 3 hex chars
 
 	// Generate the variant bits (8, 9, A, or B)
 	variantChars := "89ab"
-	variant := randStringFromCharset(variantChars, 1)
-	parts = append(parts, variant+randStringFromCharset(hexChars, 3)) // Variant + 3 hex chars
-	parts = append(parts, randStringFromCharset(hexChars, 12))        // 12 hex chars
+	variant := hex(variantChars, 1)
+	parts = append(parts, variant+hex(hexChars, 3)) // Variant + 3 hex chars
+	parts = append(parts, hex(hexChars, 12))        // 12 hex chars
 
-	return strings.Join(parts, "-")
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(parts, "-"), nil
 }
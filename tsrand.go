@@ -25,11 +25,15 @@
 package rand
 
 import (
-	cRand "crypto/rand"
-	"math/big"
+	"encoding/binary"
+	"io"
+	"math"
+	"math/bits"
 	"math/rand"
+	"strings"
 	"sync"
 	"time"
+	"unicode"
 )
 
 // Character sets used for string generation
@@ -42,19 +46,33 @@ const (
 	VisibleLetters = "23456789abcdefghjkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
 )
 
+// isVisible reports whether r is not easily confused: a letter or digit in
+// VisibleLetters, or neither letter nor digit
+func isVisible(r rune) bool {
+	return !(unicode.IsLetter(r) || unicode.IsDigit(r)) || strings.ContainsRune(VisibleLetters, r)
+}
+
 var (
-	// Global pseudo-random generator used as fallback when crypto/rand fails
+	// Global pseudo-random generator used as fallback when crypto/rand fails.
+	// *rand.Rand is not safe for concurrent use, so fallbackMu guards reads from it.
 	fallbackRand     *rand.Rand
 	fallbackRandOnce sync.Once
+	fallbackMu       sync.Mutex
 
-	// Object pools for reducing allocations
-	bigIntPool = sync.Pool{
+	// Pool of read buffers so that drawing from a Source does not allocate
+	uint64BufPool = sync.Pool{
 		New: func() interface{} {
-			return new(big.Int)
+			return new([8]byte)
 		},
 	}
 )
 
+// uint64Source is implemented by sources that can produce 64 random bits
+// directly, such as PCG, letting the generator skip the byte buffer
+type uint64Source interface {
+	Uint64() uint64
+}
+
 // initFallbackRand initializes the fallback random generator
 func initFallbackRand() {
 	fallbackRand = rand.New(rand.NewSource(time.Now().UnixNano()))
@@ -66,20 +84,110 @@ func getFallbackRand() *rand.Rand {
 	return fallbackRand
 }
 
-// getBigInt gets a big.Int from the pool
-func getBigInt() *big.Int {
-	return bigIntPool.Get().(*big.Int)
+// fallbackUint64 returns 64 pseudo-random bits from the shared fallback generator
+func fallbackUint64() uint64 {
+	r := getFallbackRand()
+	fallbackMu.Lock()
+	defer fallbackMu.Unlock()
+	return r.Uint64()
+}
+
+// uint64 returns 64 random bits from the generator's Source.
+// If the Source fails, a strict generator returns an error wrapping ErrEntropyUnavailable;
+// otherwise the bits are drawn from the math/rand fallback instead.
+// It does not allocate.
+func (g *Generator) uint64() (uint64, error) {
+	var n uint64
+	var err error
+
+	switch src := g.src.(type) {
+	case *entropyPool:
+		n, err = src.uint64()
+	case uint64Source:
+		return src.Uint64(), nil
+	default:
+		buf := uint64BufPool.Get().(*[8]byte)
+		_, err = io.ReadFull(src, buf[:])
+		n = binary.LittleEndian.Uint64(buf[:])
+		uint64BufPool.Put(buf)
+	}
+	if err == nil {
+		return n, nil
+	}
+	if g.isStrict() {
+		return 0, entropyError(err)
+	}
+
+	// Fallback to pseudo-random generation
+	return fallbackUint64(), nil
+}
+
+// fallbackRead fills p with pseudo-random bytes from the shared fallback generator
+func fallbackRead(p []byte) {
+	r := getFallbackRand()
+	fallbackMu.Lock()
+	defer fallbackMu.Unlock()
+	r.Read(p)
 }
 
-// putBigInt returns a big.Int to the pool after resetting it
-func putBigInt(bi *big.Int) {
-	bi.SetInt64(0)
-	bigIntPool.Put(bi)
+// read fills p from the generator's Source.
+// If the Source fails, a strict generator returns an error wrapping ErrEntropyUnavailable;
+// otherwise p is filled from the math/rand fallback instead.
+func (g *Generator) read(p []byte) error {
+	if _, err := io.ReadFull(g.src, p); err != nil {
+		if g.isStrict() {
+			return entropyError(err)
+		}
+
+		// Fallback to pseudo-random generation
+		fallbackRead(p)
+	}
+	return nil
 }
 
-// secureRandomBigInt generates a cryptographically secure random number in [0, max)
-// Returns the random number and a boolean indicating success
-func secureRandomBigInt(max *big.Int) (*big.Int, bool) {
-	result, err := cRand.Int(cRand.Reader, max)
-	return result, err == nil
+// uint64n returns a uniform random number in [0, n) for n > 0.
+//
+// It uses Lemire's multiply-shift method: the high word of x*n is uniform in
+// [0, n) once the rare candidates whose low word falls below 2^64 mod n are
+// rejected. Unlike modulo reduction this is unbiased, and unlike crypto/rand.Int
+// it needs no big.Int and therefore no allocation.
+func (g *Generator) uint64n(n uint64) (uint64, error) {
+	x, err := g.uint64()
+	if err != nil {
+		return 0, err
+	}
+	if n&(n-1) == 0 { // n is a power of two, masking is exact
+		return x & (n - 1), nil
+	}
+
+	hi, lo := bits.Mul64(x, n)
+	if lo < n {
+		thresh := -n % n
+		for lo < thresh {
+			if x, err = g.uint64(); err != nil {
+				return 0, err
+			}
+			hi, lo = bits.Mul64(x, n)
+		}
+	}
+	return hi, nil
+}
+
+// uint64Closed returns a uniform random number in the closed interval [0, span].
+// A span of math.MaxUint64 covers every uint64 and is served by the raw source.
+func (g *Generator) uint64Closed(span uint64) (uint64, error) {
+	if span == math.MaxUint64 {
+		return g.uint64()
+	}
+	return g.uint64n(span + 1)
+}
+
+// must unwraps the result of an error-returning variant. Errors only occur when a
+// strict generator's Source fails, in which case the infallible variants panic
+// rather than fall back to a predictable generator.
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
 }
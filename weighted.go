@@ -0,0 +1,209 @@
+package rand
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// ErrInvalidWeights is returned when weights cannot describe a probability distribution
+var ErrInvalidWeights = errors.New("invalid weights")
+
+// Weight is the constraint for the weights of a WeightedChooser:
+// any integer or floating-point type.
+type Weight interface {
+	Integer | ~float32 | ~float64
+}
+
+// WeightedChooser picks items at random in proportion to fixed weights.
+//
+// It is built once in O(n) with Vose's alias method, after which every pick
+// takes O(1) time and two random draws regardless of the number of items.
+// Integer weights are used exactly: an item with weight w out of a total W is
+// picked with probability exactly w/W. Floating-point weights are converted
+// to integers with at least 2^62/n total resolution.
+//
+// A WeightedChooser is immutable and safe for concurrent use.
+type WeightedChooser[T any] struct {
+	items []T
+	total uint64   // capacity of every bucket: the sum of the integer weights
+	prob  []uint64 // bucket i keeps items[i] for draws below prob[i] out of total
+	alias []int    // item taking the rest of bucket i
+}
+
+// NewWeightedChooser returns a WeightedChooser over items with the matching weights.
+//
+// It returns an error wrapping ErrInvalidWeights if items is empty, the two
+// slices differ in length, any weight is negative, NaN or infinite, all
+// weights are zero, or the integer weights are too large to sum exactly.
+// Items with zero weight are never picked.
+//
+// Example:
+//
+//	drops, err := rand.NewWeightedChooser(
+//		[]string{"common", "rare", "legendary"},
+//		[]int{90, 9, 1},
+//	)
+//	if err != nil {
+//		// Handle error
+//	}
+//	item := drops.Pick() // "common" 90% of the time
+func NewWeightedChooser[T any, W Weight](items []T, weights []W) (*WeightedChooser[T], error) {
+	if len(items) != len(weights) {
+		return nil, fmt.Errorf("%w: %d items but %d weights", ErrInvalidWeights, len(items), len(weights))
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: no items", ErrInvalidWeights)
+	}
+
+	scaled, total, err := integerWeights(weights)
+	if err != nil {
+		return nil, err
+	}
+
+	n := uint64(len(items))
+	if hi, _ := bits.Mul64(n, total); hi != 0 {
+		return nil, fmt.Errorf("%w: total weight too large", ErrInvalidWeights)
+	}
+
+	c := &WeightedChooser[T]{
+		items: append([]T(nil), items...),
+		total: total,
+		prob:  make([]uint64, n),
+		alias: make([]int, n),
+	}
+
+	// Scale every weight by n so the average weight equals the bucket capacity
+	// total, keeping all of Vose's arithmetic in exact integers
+	small := make([]int, 0, n)
+	large := make([]int, 0, n)
+	for i := range scaled {
+		scaled[i] *= n
+		if scaled[i] < total {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		s := small[len(small)-1]
+		small = small[:len(small)-1]
+		l := large[len(large)-1]
+		large = large[:len(large)-1]
+
+		// Bucket s is topped up with item l
+		c.prob[s] = scaled[s]
+		c.alias[s] = l
+		scaled[l] -= total - scaled[s]
+
+		if scaled[l] < total {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+
+	// Exact arithmetic leaves only full buckets behind
+	for _, i := range append(small, large...) {
+		c.prob[i] = total
+		c.alias[i] = i
+	}
+	return c, nil
+}
+
+// integerWeights validates weights and returns them as integers with their sum.
+// Integer weights are returned unchanged; floating-point weights are scaled so
+// that their sum is close to 2^62/n, and positive weights never round to zero.
+func integerWeights[W Weight](weights []W) ([]uint64, uint64, error) {
+	var max float64
+	for i, w := range weights {
+		f := float64(w)
+		if w != w || math.IsInf(f, 0) {
+			return nil, 0, fmt.Errorf("%w: weight %d is %v", ErrInvalidWeights, i, f)
+		}
+		if w < 0 {
+			return nil, 0, fmt.Errorf("%w: weight %d is negative", ErrInvalidWeights, i)
+		}
+		if f > max {
+			max = f
+		}
+	}
+	if max == 0 {
+		return nil, 0, fmt.Errorf("%w: all weights are zero", ErrInvalidWeights)
+	}
+
+	scaled := make([]uint64, len(weights))
+	var total uint64
+
+	// One half is zero in integer arithmetic and non-zero in floating point
+	var one W = 1
+	if one/(one+one) == 0 {
+		for i, w := range weights {
+			var carry uint64
+			scaled[i] = uint64(w)
+			total, carry = bits.Add64(total, scaled[i], 0)
+			if carry != 0 {
+				return nil, 0, fmt.Errorf("%w: total weight too large", ErrInvalidWeights)
+			}
+		}
+		return scaled, total, nil
+	}
+
+	// Normalizing by the largest weight first keeps the sum finite
+	var sum float64
+	for _, w := range weights {
+		sum += float64(w) / max
+	}
+	scale := float64(uint64(1)<<62/uint64(len(weights))) / sum
+	for i, w := range weights {
+		scaled[i] = uint64(math.Round(float64(w) / max * scale))
+		if scaled[i] == 0 && w > 0 {
+			scaled[i] = 1
+		}
+		total += scaled[i]
+	}
+	return scaled, total, nil
+}
+
+// Pick returns an item chosen with probability proportional to its weight.
+// In strict mode (see SetStrict) it panics if the entropy source fails.
+func (c *WeightedChooser[T]) Pick() T {
+	return c.PickWith(defaultGenerator)
+}
+
+// PickE is like Pick but returns an error instead of falling back to math/rand
+// when the entropy source fails. Errors are only reported in strict mode (see SetStrict).
+func (c *WeightedChooser[T]) PickE() (T, error) {
+	return c.PickEWith(defaultGenerator)
+}
+
+// PickWith is like Pick but draws from g.
+func (c *WeightedChooser[T]) PickWith(g *Generator) T {
+	return must(c.PickEWith(g))
+}
+
+// PickEWith is like PickE but draws from g.
+func (c *WeightedChooser[T]) PickEWith(g *Generator) (T, error) {
+	var zero T
+
+	i, err := g.uint64n(uint64(len(c.items)))
+	if err != nil {
+		return zero, err
+	}
+	r, err := g.uint64n(c.total)
+	if err != nil {
+		return zero, err
+	}
+
+	if r < c.prob[i] {
+		return c.items[i], nil
+	}
+	return c.items[c.alias[i]], nil
+}
+
+// Len returns the number of items, including those with zero weight.
+func (c *WeightedChooser[T]) Len() int {
+	return len(c.items)
+}
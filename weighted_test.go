@@ -0,0 +1,168 @@
+package rand
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// aliasMass returns the probability mass of every item in the alias table,
+// in units of 1/(n*total), computed exactly
+func aliasMass[T any](c *WeightedChooser[T]) []uint64 {
+	mass := make([]uint64, len(c.items))
+	for i := range c.items {
+		mass[i] += c.prob[i]
+		mass[c.alias[i]] += c.total - c.prob[i]
+	}
+	return mass
+}
+
+// TestWeightedChooserExact validates that integer weights are represented exactly
+func TestWeightedChooserExact(t *testing.T) {
+	testCases := [][]uint64{
+		{1},
+		{1, 1},
+		{90, 9, 1},
+		{0, 3, 0, 7},
+		{1, 1 << 40, 3, 5, 7, 11},
+		{math.MaxUint32, 1, math.MaxUint32 - 1},
+	}
+
+	for _, weights := range testCases {
+		items := make([]int, len(weights))
+		c, err := NewWeightedChooser(items, weights)
+		require.NoError(t, err, "weights %v", weights)
+
+		n := uint64(len(weights))
+		for i, m := range aliasMass(c) {
+			assert.Equal(t, weights[i]*n, m, "weights %v: item %d has inexact mass", weights, i)
+		}
+	}
+}
+
+// TestWeightedChooserDistribution validates pick frequencies
+func TestWeightedChooserDistribution(t *testing.T) {
+	const iterations = 100000
+
+	c, err := NewWeightedChooser([]string{"a", "b", "c", "d"}, []int{50, 30, 20, 0})
+	require.NoError(t, err)
+	assert.Equal(t, 4, c.Len())
+
+	counts := make(map[string]int)
+	for i := 0; i < iterations; i++ {
+		counts[c.Pick()]++
+	}
+	assert.InDelta(t, 50000, counts["a"], 800)
+	assert.InDelta(t, 30000, counts["b"], 800)
+	assert.InDelta(t, 20000, counts["c"], 800)
+	assert.Zero(t, counts["d"], "zero-weight items must never be picked")
+
+	// Floating-point weights
+	f, err := NewWeightedChooser([]int{0, 1, 2}, []float64{0.25, 0.5, 0.25})
+	require.NoError(t, err)
+	var fcounts [3]int
+	for i := 0; i < iterations; i++ {
+		fcounts[f.Pick()]++
+	}
+	assert.InDelta(t, 25000, fcounts[0], 800)
+	assert.InDelta(t, 50000, fcounts[1], 800)
+	assert.InDelta(t, 25000, fcounts[2], 800)
+}
+
+// TestWeightedChooserFloatWeights validates the float to integer conversion
+func TestWeightedChooserFloatWeights(t *testing.T) {
+	weights := []float64{math.MaxFloat64, math.MaxFloat64, 1e-300, 0}
+	c, err := NewWeightedChooser([]int{0, 1, 2, 3}, weights)
+	require.NoError(t, err, "huge weights should not overflow")
+
+	mass := aliasMass(c)
+	assert.Equal(t, mass[0], mass[1])
+	assert.NotZero(t, mass[2], "tiny positive weights should stay reachable")
+	assert.Zero(t, mass[3])
+
+	_, err = NewWeightedChooser([]int{0, 1}, []float32{0.1, 0.9})
+	assert.NoError(t, err)
+}
+
+// TestWeightedChooserInvalid validates input checks
+func TestWeightedChooserInvalid(t *testing.T) {
+	testCases := map[string]func() error{
+		"empty": func() error {
+			_, err := NewWeightedChooser([]int{}, []int{})
+			return err
+		},
+		"length mismatch": func() error {
+			_, err := NewWeightedChooser([]int{1, 2}, []int{1})
+			return err
+		},
+		"negative": func() error {
+			_, err := NewWeightedChooser([]int{1, 2}, []int{1, -1})
+			return err
+		},
+		"NaN": func() error {
+			_, err := NewWeightedChooser([]int{1, 2}, []float64{1, math.NaN()})
+			return err
+		},
+		"infinite": func() error {
+			_, err := NewWeightedChooser([]int{1, 2}, []float64{math.Inf(1), 1})
+			return err
+		},
+		"all zero": func() error {
+			_, err := NewWeightedChooser([]int{1, 2}, []float64{0, 0})
+			return err
+		},
+		"sum overflow": func() error {
+			_, err := NewWeightedChooser([]int{1, 2}, []uint64{math.MaxUint64, 1})
+			return err
+		},
+		"scaled overflow": func() error {
+			_, err := NewWeightedChooser([]int{1, 2}, []uint64{1 << 63, 1})
+			return err
+		},
+	}
+
+	for name, fn := range testCases {
+		assert.ErrorIs(t, fn(), ErrInvalidWeights, name)
+	}
+}
+
+// TestWeightedChooserGenerator validates seeded and strict picks
+func TestWeightedChooserGenerator(t *testing.T) {
+	c, err := NewWeightedChooser([]string{"x", "y", "z"}, []uint8{1, 2, 3})
+	require.NoError(t, err)
+
+	a, b := NewSeeded(9), NewSeeded(9)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, c.PickWith(a), c.PickWith(b))
+	}
+
+	strict := NewGenerator(failingSource{}, WithStrict())
+	_, err = c.PickEWith(strict)
+	assert.ErrorIs(t, err, ErrEntropyUnavailable)
+	assert.Panics(t, func() { c.PickWith(strict) })
+
+	// Items are copied at construction
+	items := []string{"x", "y"}
+	c, err = NewWeightedChooser(items, []int{1, 0})
+	require.NoError(t, err)
+	items[0] = "changed"
+	assert.Equal(t, "x", c.Pick())
+}
+
+// BenchmarkWeightedChooser benchmarks picks from a large table
+func BenchmarkWeightedChooser(b *testing.B) {
+	weights := make([]int, 10000)
+	for i := range weights {
+		weights[i] = i + 1
+	}
+	c, err := NewWeightedChooser(weights, weights)
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = c.Pick()
+	}
+}
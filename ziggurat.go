@@ -0,0 +1,179 @@
+package rand
+
+import (
+	"math"
+)
+
+// Ziggurat tables after Marsaglia and Tsang, "The Ziggurat Method for
+// Generating Random Variables" (2000), adapted to 53-bit draws.
+//
+// Each density is covered by equal-area layers. Layer i is accepted at once
+// when the draw falls within the part of the layer lying under the curve
+// (j < k[i]); otherwise the sample is tested against the wedge between the
+// layer edges f[i] and f[i-1], or drawn from the tail for the base layer 0.
+const (
+	// zigScale is the range of the uniform integer j drawn per layer
+	zigScale = 1 << 53
+
+	zigNormLayers = 128
+	zigNormR      = 3.442619855899      // Start of the normal tail
+	zigNormV      = 9.91256303526217e-3 // Area of every layer
+	zigExpLayers  = 256
+	zigExpR       = 7.697117470131487    // Start of the exponential tail
+	zigExpV       = 3.949659822581572e-3 // Area of every layer
+)
+
+// zigNormTable holds the acceptance thresholds k, widths w and densities f
+// of each layer of the normal ziggurat
+type zigNormTable struct {
+	k    [zigNormLayers]uint64
+	w, f [zigNormLayers]float64
+}
+
+// zigExpTable is the exponential counterpart of zigNormTable
+type zigExpTable struct {
+	k    [zigExpLayers]uint64
+	w, f [zigExpLayers]float64
+}
+
+var (
+	zigNorm = newZigNorm()
+	zigExp  = newZigExp()
+)
+
+// newZigNorm computes the tables for the half-normal density exp(-x²/2)
+func newZigNorm() *zigNormTable {
+	t := new(zigNormTable)
+	dn := zigNormR
+	tn := dn
+	q := zigNormV / math.Exp(-0.5*dn*dn)
+
+	t.k[0] = uint64(dn / q * zigScale)
+	t.w[0] = q / zigScale
+	t.w[zigNormLayers-1] = dn / zigScale
+	t.f[0] = 1
+	t.f[zigNormLayers-1] = math.Exp(-0.5 * dn * dn)
+
+	for i := zigNormLayers - 2; i >= 1; i-- {
+		dn = math.Sqrt(-2 * math.Log(zigNormV/dn+math.Exp(-0.5*dn*dn)))
+		t.k[i+1] = uint64(dn / tn * zigScale)
+		tn = dn
+		t.f[i] = math.Exp(-0.5 * dn * dn)
+		t.w[i] = dn / zigScale
+	}
+	return t
+}
+
+// newZigExp computes the tables for the exponential density exp(-x)
+func newZigExp() *zigExpTable {
+	t := new(zigExpTable)
+	de := zigExpR
+	te := de
+	q := zigExpV / math.Exp(-de)
+
+	t.k[0] = uint64(de / q * zigScale)
+	t.w[0] = q / zigScale
+	t.w[zigExpLayers-1] = de / zigScale
+	t.f[0] = 1
+	t.f[zigExpLayers-1] = math.Exp(-de)
+
+	for i := zigExpLayers - 2; i >= 1; i-- {
+		de = -math.Log(zigExpV/de + math.Exp(-de))
+		t.k[i+1] = uint64(de / te * zigScale)
+		te = de
+		t.f[i] = math.Exp(-de)
+		t.w[i] = de / zigScale
+	}
+	return t
+}
+
+// normFloat64 returns a standard normal variate.
+// One 64-bit draw supplies the layer (7 bits), the sign (1 bit) and the
+// position within the layer (53 bits), so they are independent.
+func (g *Generator) normFloat64() (float64, error) {
+	for {
+		u, err := g.uint64()
+		if err != nil {
+			return 0, err
+		}
+
+		i := u & (zigNormLayers - 1)
+		j := u >> 11
+		x := float64(j) * zigNorm.w[i]
+
+		if j >= zigNorm.k[i] {
+			if i == 0 {
+				if x, err = g.normTail(); err != nil {
+					return 0, err
+				}
+			} else {
+				v, err := g.Float64E()
+				if err != nil {
+					return 0, err
+				}
+				if zigNorm.f[i]+v*(zigNorm.f[i-1]-zigNorm.f[i]) >= math.Exp(-0.5*x*x) {
+					continue
+				}
+			}
+		}
+
+		if u&zigNormLayers != 0 {
+			return -x, nil
+		}
+		return x, nil
+	}
+}
+
+// normTail samples the normal tail beyond zigNormR with Marsaglia's method
+func (g *Generator) normTail() (float64, error) {
+	for {
+		u, err := g.openFloat64()
+		if err != nil {
+			return 0, err
+		}
+		v, err := g.openFloat64()
+		if err != nil {
+			return 0, err
+		}
+
+		x := -math.Log(u) / zigNormR
+		y := -math.Log(v)
+		if y+y >= x*x {
+			return zigNormR + x, nil
+		}
+	}
+}
+
+// expFloat64 returns an exponential variate with rate 1
+func (g *Generator) expFloat64() (float64, error) {
+	for {
+		u, err := g.uint64()
+		if err != nil {
+			return 0, err
+		}
+
+		i := u & (zigExpLayers - 1)
+		j := u >> 11
+		x := float64(j) * zigExp.w[i]
+		if j < zigExp.k[i] {
+			return x, nil
+		}
+
+		if i == 0 {
+			// The tail of an exponential is another exponential
+			v, err := g.openFloat64()
+			if err != nil {
+				return 0, err
+			}
+			return zigExpR - math.Log(v), nil
+		}
+
+		v, err := g.Float64E()
+		if err != nil {
+			return 0, err
+		}
+		if zigExp.f[i]+v*(zigExp.f[i-1]-zigExp.f[i]) < math.Exp(-x) {
+			return x, nil
+		}
+	}
+}